@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+)
+
+var cmdBench = &command{
+	name:  "bench",
+	usage: "bench [-benchtime d] [-md] [id|category...]",
+	short: "Tipsのベンチマークをとり、ns/opとallocs/opの表を表示する",
+	run:   runBench,
+}
+
+func runBench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	benchtime := fs.Duration("benchtime", time.Second, "1つのTipsを測る時間の目安 (go test -benchtime と同じ)")
+	md := fs.Bool("md", false, "markdownの表で出力する")
+	fs.Parse(args)
+
+	ts, err := selectTips(fs.Args())
+	if err != nil {
+		return err
+	}
+	var rs []*tips.BenchResult
+	for _, t := range tips.WithVariants(ts) {
+		fmt.Fprintf(os.Stderr, "%s\n", t.ID)
+		rs = append(rs, tips.Benchmark(t, *benchtime))
+	}
+
+	if *md {
+		writeBenchMarkdown(os.Stdout, rs)
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "id\ttarget\tns/op\tallocs/op\tB/op\tvs classic\t")
+	var skipped []*tips.BenchResult
+	var classic *tips.BenchResult
+	for _, r := range rs {
+		if r.Tip.Go == "" {
+			classic = r
+		}
+		if r.Skip != "" {
+			skipped = append(skipped, r)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%s\t\n", r.Tip.ID, target(r),
+			r.NsPerOp(), r.AllocsPerOp(), r.AllocedBytesPerOp(), compare(r, classic))
+	}
+	w.Flush()
+
+	if len(skipped) > 0 {
+		fmt.Println()
+		fmt.Println("skipped:")
+		for _, r := range skipped {
+			fmt.Printf("  %s: %s\n", r.Tip.ID, r.Skip)
+		}
+	}
+	return nil
+}
+
+func target(r *tips.BenchResult) string {
+	if r.Helper {
+		return "helper"
+	}
+	return "tip"
+}
+
+// 新しい書き方の版を、同じものを測ったclassicと比べる (例: x0.25)
+func compare(r, classic *tips.BenchResult) string {
+	if r.Tip.Go == "" || classic == nil || classic.Skip != "" || classic.Helper != r.Helper || classic.NsPerOp() == 0 {
+		return ""
+	}
+	return fmt.Sprintf("x%.2f", float64(r.NsPerOp())/float64(classic.NsPerOp()))
+}
+
+func writeBenchMarkdown(w io.Writer, rs []*tips.BenchResult) {
+	fmt.Fprintln(w, "| Tips | 対象 | ns/op | allocs/op | B/op | classic比 |")
+	fmt.Fprintln(w, "|---|---|---:|---:|---:|---:|")
+	var classic *tips.BenchResult
+	for _, r := range rs {
+		if r.Tip.Go == "" {
+			classic = r
+		}
+		if r.Skip != "" {
+			continue
+		}
+		fmt.Fprintf(w, "| `%s` | %s | %d | %d | %d | %s |\n", r.Tip.ID, target(r),
+			r.NsPerOp(), r.AllocsPerOp(), r.AllocedBytesPerOp(), compare(r, classic))
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+)
+
+// 全てのTipsをEPUB3の本にする
+//
+// カテゴリごとに1つの章(XHTML)にします。EPUBではスクリプトやcssのタブが使えないので、
+// 新しい書き方の版は見出しを付けて順に並べます。コードの色付けもしません。
+// 更新日時は環境変数 SOURCE_DATE_EPOCH があればその時刻で、同じ入力からは同じファイルになります。
+func makeEPUB() error {
+	modified := time.Now().UTC()
+	if s := os.Getenv("SOURCE_DATE_EPOCH"); s != "" {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("SOURCE_DATE_EPOCH: %v", err)
+		}
+		modified = time.Unix(n, 0).UTC()
+	}
+
+	// 見出しの {#id} をidにし、XHTMLとして正しい形で出力する
+	md := goldmark.New(
+		goldmark.WithParserOptions(parser.WithAttribute()),
+		goldmark.WithRendererOptions(html.WithXHTML()),
+	)
+	var chapters []*chapter
+	for _, c := range tips.Categories() {
+		var src bytes.Buffer
+		writeChapter(&src, c)
+		var out bytes.Buffer
+		if err := md.Convert(src.Bytes(), &out); err != nil {
+			return err
+		}
+		body := relink(out.Bytes(), func(page, frag string) string {
+			if frag != "" {
+				return page + ".xhtml#" + frag
+			}
+			return page + ".xhtml"
+		})
+		chapters = append(chapters, &chapter{Name: "tips_" + c.Name, Title: c.Title, Body: template.HTML(body)})
+	}
+
+	css, err := ioutil.ReadFile(filepath.Join(*htmlFolder, "github.css"))
+	if err != nil {
+		return err
+	}
+	cover, err := ioutil.ReadFile(filepath.Join(*htmlFolder, "gopher.png"))
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	z := zip.NewWriter(&buf)
+	add := func(name string, method uint16, b []byte) error {
+		w, err := z.CreateHeader(&zip.FileHeader{Name: name, Method: method, Modified: modified})
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	}
+	execute := func(name string, data interface{}) error {
+		b := bytes.NewBufferString(xmlHeader)
+		if err := epubTemplates.ExecuteTemplate(b, name, data); err != nil {
+			return err
+		}
+		return add(name, zip.Deflate, b.Bytes())
+	}
+
+	// mimetype は圧縮せずに最初に置く決まり
+	if err := add("mimetype", zip.Store, []byte("application/epub+zip")); err != nil {
+		return err
+	}
+	if err := execute("META-INF/container.xml", nil); err != nil {
+		return err
+	}
+	err = execute("OEBPS/content.opf", struct {
+		Modified string
+		Chapters []*chapter
+	}{modified.Format("2006-01-02T15:04:05Z"), chapters})
+	if err != nil {
+		return err
+	}
+	if err := execute("OEBPS/nav.xhtml", chapters); err != nil {
+		return err
+	}
+	for _, c := range chapters {
+		b := bytes.NewBufferString(xmlHeader)
+		if err := epubTemplates.ExecuteTemplate(b, "chapter", c); err != nil {
+			return err
+		}
+		if err := add("OEBPS/"+c.Name+".xhtml", zip.Deflate, b.Bytes()); err != nil {
+			return err
+		}
+	}
+	if err := add("OEBPS/github.css", zip.Deflate, css); err != nil {
+		return err
+	}
+	if err := add("OEBPS/gopher.png", zip.Store, cover); err != nil {
+		return err
+	}
+	if err := z.Close(); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(*bookFolder, "golangtips.epub"), buf.Bytes(), 0666)
+}
+
+// writeChapter はEPUBの章にするmarkdownを書き出します。
+// htmlのタグを使わず、見出しのidは {#id} で付けます。
+func writeChapter(w *bytes.Buffer, c *tips.Category) {
+	for _, t := range c.Tips {
+		if t.ID != "" {
+			fmt.Fprintf(w, "## %s {#%s}\n\n", t.Title, t.ID)
+		} else {
+			fmt.Fprintf(w, "## %s\n\n", t.Title)
+		}
+		if len(t.Ruby) > 0 {
+			fmt.Fprintf(w, "Rubyでは: %s\n\n", rubyMethods(t.Ruby))
+		}
+		fmt.Fprintf(w, "%s\n\n", t.Description)
+		for i, v := range tips.WithVariants([]*tips.Tip{t}) {
+			if v.Stub() {
+				continue
+			}
+			if i > 0 {
+				fmt.Fprintf(w, "### modern (%s〜) {#%s}\n\n", v.Go, v.ID)
+				if strings.TrimSpace(v.Description) != "" {
+					fmt.Fprintf(w, "%s\n\n", v.Description)
+				}
+			}
+			fmt.Fprintf(w, "```golang\n%s```\n\n", v.Snippet())
+		}
+	}
+}
+
+// html/templateは <?xml をエスケープしてしまうので、テンプレートの外で付ける
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8"?>` + "\n"
+
+// EPUBの枠になるファイル。章の本文(chapter.Body)はgoldmarkの出力をそのまま埋め込む
+var epubTemplates = template.Must(template.New("epub").Parse(`
+{{- define "META-INF/container.xml" -}}
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+<rootfiles>
+<rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+</rootfiles>
+</container>
+{{end}}
+
+{{- define "OEBPS/content.opf" -}}
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="id" xml:lang="ja">
+<metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+<dc:identifier id="id">http://ashitani.jp/golangtips/</dc:identifier>
+<dc:title>逆引きGolang</dc:title>
+<dc:creator>T.Ashitani</dc:creator>
+<dc:language>ja</dc:language>
+<meta property="dcterms:modified">{{.Modified}}</meta>
+<meta name="cover" content="cover"/>
+</metadata>
+<manifest>
+<item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+<item id="css" href="github.css" media-type="text/css"/>
+<item id="cover" href="gopher.png" media-type="image/png" properties="cover-image"/>
+{{- range .Chapters}}
+<item id="{{.Name}}" href="{{.Name}}.xhtml" media-type="application/xhtml+xml"/>
+{{- end}}
+</manifest>
+<spine>
+<itemref idref="nav"/>
+{{- range .Chapters}}
+<itemref idref="{{.Name}}"/>
+{{- end}}
+</spine>
+</package>
+{{end}}
+
+{{- define "OEBPS/nav.xhtml" -}}
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="ja" lang="ja">
+<head>
+<title>逆引きGolang</title>
+<link rel="stylesheet" href="github.css"/>
+</head>
+<body>
+<h1>逆引きGolang</h1>
+<nav epub:type="toc" id="toc">
+<ol>
+{{- range .}}
+<li><a href="{{.Name}}.xhtml">{{.Title}}</a></li>
+{{- end}}
+</ol>
+</nav>
+</body>
+</html>
+{{end}}
+
+{{- define "chapter" -}}
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xml:lang="ja" lang="ja">
+<head>
+<title>{{.Title}}</title>
+<link rel="stylesheet" href="github.css"/>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{.Body}}
+</body>
+</html>
+{{end}}
+`))
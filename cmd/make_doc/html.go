@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/renderer/html"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+)
+
+// page は template/page.html に渡すデータです。
+type page struct {
+	Title      string
+	Body       template.HTML
+	Categories []*tips.Category // 左のフレームの目次
+
+	// テンプレートの中身をそのまま埋め込むもの
+	Header    template.HTML
+	Adsense   template.HTML
+	AfterBody template.HTML
+}
+
+// markdownからhtmlを生成する
+//
+// 以前はpandocで行っていたものです。template/page.html が全体の枠で、
+// header.html, before_body.html, adsense.html(あれば), after_body.html を取り込みます。
+// 左のフレームの目次を作る before_body.html 以外は、pandocと同じく中身をそのまま埋め込みます。
+// 同じ入力からは常に同じバイト列を出力します。
+func makeHTML() error {
+	tmpl, err := template.ParseFiles(
+		filepath.Join(*templateFolder, "page.html"),
+		filepath.Join(*templateFolder, "before_body.html"),
+	)
+	if err != nil {
+		return err
+	}
+	header, err := readRaw("header.html")
+	if err != nil {
+		return err
+	}
+	afterBody, err := readRaw("after_body.html")
+	if err != nil {
+		return err
+	}
+	adsense, err := readRaw("adsense.html")
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.MkdirAll(*htmlFolder, 0777); err != nil {
+		return err
+	}
+
+	// 説明に書いた <a name="..."> などのhtmlはそのまま出力する
+	md := goldmark.New(goldmark.WithRendererOptions(html.WithUnsafe()))
+
+	mds, _ := filepath.Glob(filepath.Join(*markdownFolder, "*.md"))
+	sort.Strings(mds)
+	for _, filename := range mds {
+		src, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		p := &page{
+			Categories: tips.Categories(),
+			Header:     header,
+			Adsense:    adsense,
+			AfterBody:  afterBody,
+		}
+		p.Title, src = splitTitle(src)
+
+		var body bytes.Buffer
+		if err := md.Convert(src, &body); err != nil {
+			return err
+		}
+		p.Body = template.HTML(body.String())
+
+		var out bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&out, "page.html", p); err != nil {
+			return err
+		}
+		name := strings.TrimSuffix(filepath.Base(filename), ".md") + ".html"
+		if err := ioutil.WriteFile(filepath.Join(*htmlFolder, name), out.Bytes(), 0666); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readRaw(name string) (template.HTML, error) {
+	b, err := ioutil.ReadFile(filepath.Join(*templateFolder, name))
+	return template.HTML(b), err
+}
+
+// pandocのタイトル行 "% タイトル" を取り出す
+func splitTitle(src []byte) (string, []byte) {
+	if !bytes.HasPrefix(src, []byte("% ")) {
+		return "", src
+	}
+	line := src
+	rest := []byte(nil)
+	if i := bytes.IndexByte(src, '\n'); i >= 0 {
+		line, rest = src[:i], src[i+1:]
+	}
+	return strings.TrimSpace(string(line[2:])), rest
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+)
+
+// jsonCategory と jsonTip はTipsをJSONで書き出すときの形です。
+type jsonCategory struct {
+	Name  string     `json:"name"`
+	Title string     `json:"title"`
+	Tips  []*jsonTip `json:"tips"`
+}
+
+type jsonTip struct {
+	ID          string     `json:"id,omitempty"` // 見出しだけのTipsでは空
+	Title       string     `json:"title"`
+	Description string     `json:"description"`        // markdown
+	Code        string     `json:"code,omitempty"`     // 全コピペで動作するプログラム
+	Imports     []string   `json:"imports,omitempty"`  // "fmt" や set "github.com/deckarep/golang-set" のようなimportの指定
+	Ruby        []string   `json:"ruby,omitempty"`     // 逆引きRubyで対応するメソッド
+	Go          string     `json:"go,omitempty"`       // 新しい書き方の版で、必要なGoのバージョン
+	Variants    []*jsonTip `json:"variants,omitempty"` // 新しい書き方の版
+	File        string     `json:"file"`
+	Line        int        `json:"line"`
+}
+
+func newJSONTip(t *tips.Tip) *jsonTip {
+	j := &jsonTip{
+		ID:          t.ID,
+		Title:       t.Title,
+		Description: t.Description,
+		Code:        t.Snippet(),
+		Imports:     t.InferImports(),
+		Ruby:        t.Ruby,
+		Go:          t.Go,
+		File:        filepath.ToSlash(t.File),
+		Line:        t.Line,
+	}
+	for _, v := range t.Variants {
+		j.Variants = append(j.Variants, newJSONTip(v))
+	}
+	return j
+}
+
+// 全てのTipsをカテゴリごとにJSONで書き出す
+//
+// ドキュメントと同じく目次の順に並べるので、同じ入力からは常に同じバイト列になります。
+func makeJSON() error {
+	cs := []*jsonCategory{}
+	for _, c := range tips.Categories() {
+		jc := &jsonCategory{Name: c.Name, Title: c.Title, Tips: []*jsonTip{}}
+		for _, t := range c.Tips {
+			jc.Tips = append(jc.Tips, newJSONTip(t))
+		}
+		cs = append(cs, jc)
+	}
+	b, err := json.MarshalIndent(cs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(*bookFolder, "golangtips.json"), append(b, '\n'), 0666)
+}
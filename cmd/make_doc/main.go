@@ -0,0 +1,222 @@
+/*
+make_doc
+
+*.go -> (make_doc) -> *.md -> (make_doc) -> *.html
+      -> (make_doc) -> book/golangtips.html, golangtips.epub, golangtips.json
+
+doc/make_doc.rb のGo版です。htmlの生成もpandocを使わずGoで行います。docフォルダで
+
+	go run ../cmd/make_doc
+
+のように実行します。
+*/
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+	_ "github.com/ashitani/golangtips/pkg/tips/all"
+)
+
+var (
+	markdownFolder = flag.String("markdown", "markdown", "markdownの出力先")
+	htmlFolder     = flag.String("html", "html", "htmlの出力先")
+	templateFolder = flag.String("template", "template", "htmlのテンプレートの場所")
+	bookFolder     = flag.String("book", "book", "1ページのhtml・EPUB・JSONの出力先")
+)
+
+func main() {
+	flag.Parse()
+
+	if err := os.MkdirAll(*markdownFolder, 0777); err != nil {
+		log.Fatal(err)
+	}
+
+	// トップページ作成
+	if err := writeFile(filepath.Join(*markdownFolder, "index.md"), writeIndex); err != nil {
+		log.Fatal(err)
+	}
+
+	// コメントに書いたimportが実際のコードと食い違っていれば警告する
+	for _, t := range tips.All() {
+		for _, w := range t.ImportWarnings() {
+			log.Println("warning:", w)
+		}
+	}
+
+	// 各ページ
+	for _, c := range tips.Categories() {
+		err := writeFile(filepath.Join(*markdownFolder, "tips_"+c.Name+".md"), func(w *bufio.Writer) {
+			writePage(w, c.Title, c.Tips)
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err := makeHTML(); err != nil {
+		log.Fatal(err)
+	}
+	if err := makeSearchIndex(); err != nil {
+		log.Fatal(err)
+	}
+
+	// 1ページのhtml、EPUB、JSON
+	if err := os.MkdirAll(*bookFolder, 0777); err != nil {
+		log.Fatal(err)
+	}
+	for _, f := range []func() error{makeSinglePage, makeEPUB, makeJSON} {
+		if err := f(); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func writeFile(filename string, f func(w *bufio.Writer)) error {
+	fw, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(fw)
+	f(w)
+	if err := w.Flush(); err != nil {
+		fw.Close()
+		return err
+	}
+	return fw.Close()
+}
+
+// rubyのputsと同じく、末尾に改行がなければ追加して書き出す
+func puts(w *bufio.Writer, s string) {
+	w.WriteString(s)
+	if !strings.HasSuffix(s, "\n") {
+		w.WriteString("\n")
+	}
+}
+
+func writeIndex(w *bufio.Writer) {
+	puts(w, indexHeader)
+	for _, c := range tips.Categories() {
+		puts(w, fmt.Sprintf("- [%s](tips_%s.html)", c.Title, c.Name))
+	}
+	puts(w, "")
+	puts(w, indexCredits)
+}
+
+func writePage(w *bufio.Writer, name string, ts []*tips.Tip) {
+	// 目次
+	puts(w, "% 逆引きGolang ("+name+")")
+	puts(w, "")
+	for _, t := range ts {
+		puts(w, fmt.Sprintf("- [%s](#%s)", t.Title, t.ID))
+	}
+	puts(w, "")
+
+	// 各Tips
+	for _, t := range ts {
+		puts(w, fmt.Sprintf("## <a name=\"%s\"> %s</a>", t.ID, t.Title))
+		if len(t.Ruby) > 0 {
+			puts(w, "Rubyでは: "+rubyMethods(t.Ruby))
+			puts(w, "")
+		}
+		puts(w, t.Description)
+		if len(t.Variants) > 0 {
+			writeVariants(w, t)
+		} else if !t.Stub() {
+			writeCode(w, t)
+		}
+		puts(w, "")
+	}
+}
+
+func writeCode(w *bufio.Writer, t *tips.Tip) {
+	puts(w, "```golang")
+	puts(w, t.Snippet())
+	puts(w, "```")
+}
+
+// 新しい書き方の版があれば、classicとmodernのタブで切り替えて表示する
+//
+// タブはheader.htmlのcssだけで切り替えます。最初は一番新しい版を表示します。
+// markdownとして読ませるため、htmlのタグとコードの間には空行を入れます。
+func writeVariants(w *bufio.Writer, t *tips.Tip) {
+	vs := tips.WithVariants([]*tips.Tip{t})
+	puts(w, `<div class="variants">`)
+	for i, v := range vs {
+		label := "classic"
+		if v.Go != "" {
+			label = "modern (" + v.Go + "〜)"
+		}
+		checked := ""
+		if i == len(vs)-1 {
+			checked = " checked"
+		}
+		puts(w, fmt.Sprintf(`<input type="radio" name="%s" id="tab_%s"%s><label for="tab_%s">%s</label>`,
+			t.ID, v.ID, checked, v.ID, label))
+	}
+	for i, v := range vs {
+		puts(w, `<div class="variant">`)
+		puts(w, "")
+		// classicの説明は見出しの下に出している
+		if i > 0 && strings.TrimSpace(v.Description) != "" {
+			puts(w, v.Description)
+		}
+		writeCode(w, v)
+		puts(w, "")
+		puts(w, "</div>")
+	}
+	puts(w, "</div>")
+}
+
+// `Array#assoc`, `Array#rassoc`
+func rubyMethods(ms []string) string {
+	var ss []string
+	for _, m := range ms {
+		// Kernel#` のようにバッククォートを含むものは `` で囲む
+		if strings.Contains(m, "`") {
+			ss = append(ss, "`` "+m+" ``")
+		} else {
+			ss = append(ss, "`"+m+"`")
+		}
+	}
+	return strings.Join(ss, ", ")
+}
+
+const indexHeader = `% 逆引きGolang
+## これはなにか
+[逆引きRuby](http://www.namaraii.com/rubytips)の内容をGolang化しつつあるものです。
+
+当方も初学者なので、いろいろといい加減なコードが含まれると思いますが
+そのつもりでご参照ください。
+Go 1.4.1 で確認しています。Golangは仕様変更が激しいので、都度仕様を確認ください。
+
+なお、ソースコードは[Githubに置いてあります](https://github.com/ashitani/golangtips)ので、
+何かあればPull Requestでお知らせください。
+
+なお、当初から予定していたファイル系とgoroutineまでなんとか書き上げたので、
+ここでいったん更新を終了します(2015/08/12)。
+
+## 目次
+`
+
+const indexCredits = `## Credits
+
+- [RubyTips](http://www.namaraii.com/rubytips) is founded by [TAKEUCHI Hitoshi](http://www.namaraii.com/).
+
+- HTMLs are generated by [goldmark](https://github.com/yuin/goldmark) and decorated by [github.css](https://gist.github.com/andyferra/2554919).
+- Golang codes are highlighted by [highlight.js](https://highlightjs.org/),
+which is released under the [BSD License](./LICENSE.highlightjs.txt).
+
+- The Go gopher was designed by [Renee French](http://reneefrench.blogspot.com/).
+The gopher vector data was made by [Takuya Ueda](http://u.hinoichi.net). 
+Licensed under the Creative Commons 3.0 Attributions license.
+- The Gppher favicon was designed by [mccoyst](https://github.com/mccoyst/Gophers)
+`
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+)
+
+// 検索ボックス(html/search.js)が読むインデックスを html/search_index.json に書き出す
+//
+// ドキュメントと同じTipsのデータから作ります。
+// json.Marshal はmapのキーを並べて出力するので、同じ入力からは常に同じバイト列になります。
+func makeSearchIndex() error {
+	b, err := json.Marshal(tips.BuildSearchIndex())
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(*htmlFolder, "search_index.json"), append(b, '\n'), 0666)
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"html/template"
+	"io/ioutil"
+	"mime"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/renderer/html"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+)
+
+// chapter は1ページのhtmlとEPUBの章です。
+type chapter struct {
+	Name  string // tips_string のようなページ名。トップページは index
+	Title string
+	Body  template.HTML
+}
+
+// 全てのTipsを1つのhtmlファイルにする
+//
+// 各ページと同じmarkdownを template/single.html に並べ、css・highlight.js・画像を
+// ファイルの中に埋め込みます。ページをまたぐリンクはファイルの中へのリンクに付け替えます。
+func makeSinglePage() error {
+	tmpl, err := template.ParseFiles(filepath.Join(*templateFolder, "single.html"))
+	if err != nil {
+		return err
+	}
+	header, err := readRaw("header.html")
+	if err != nil {
+		return err
+	}
+
+	md := goldmark.New(goldmark.WithRendererOptions(html.WithUnsafe()))
+	var chapters []*chapter
+	for _, c := range pages() {
+		var src bytes.Buffer
+		w := bufio.NewWriter(&src)
+		c.write(w)
+		w.Flush()
+		title, body := splitTitle(src.Bytes())
+
+		var out bytes.Buffer
+		if err := md.Convert(body, &out); err != nil {
+			return err
+		}
+		chapters = append(chapters, &chapter{Name: c.name, Title: title, Body: template.HTML(out.String())})
+	}
+
+	var out bytes.Buffer
+	err = tmpl.Execute(&out, struct {
+		Header   template.HTML
+		Chapters []*chapter
+	}{header, chapters})
+	if err != nil {
+		return err
+	}
+	b := relink(out.Bytes(), func(page, frag string) string {
+		if frag != "" {
+			return "#" + frag
+		}
+		return "#" + page
+	})
+	b, err = inline(b)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(*bookFolder, "golangtips.html"), b, 0666)
+}
+
+// mdPage はドキュメントの1ページを、markdownを書き出す関数とともに表したものです。
+type mdPage struct {
+	name  string
+	write func(w *bufio.Writer)
+}
+
+// pages はトップページと各カテゴリのページを目次の順に返します。
+func pages() []mdPage {
+	ps := []mdPage{{"index", writeIndex}}
+	for _, c := range tips.Categories() {
+		c := c
+		ps = append(ps, mdPage{"tips_" + c.Name, func(w *bufio.Writer) {
+			writePage(w, c.Title, c.Tips)
+		}})
+	}
+	return ps
+}
+
+// 他のページへのリンク。公開先のURLで書いたものも含む
+var pageLink = regexp.MustCompile(`href="(?:http://ashitani\.jp/golangtips/|\./)?(index|tips_[a-z]+)\.html(?:#([^"]*))?"`)
+
+// relink はページをまたぐリンクを、ページ名と#の後ろからlinkで作ったものに付け替えます。
+func relink(b []byte, link func(page, frag string) string) []byte {
+	return pageLink.ReplaceAllFunc(b, func(m []byte) []byte {
+		sm := pageLink.FindSubmatch(m)
+		return []byte(`href="` + link(string(sm[1]), string(sm[2])) + `"`)
+	})
+}
+
+// htmlの中でファイルを読み込んでいるタグ
+var (
+	stylesheetTag = regexp.MustCompile(`<link rel="stylesheet" href="([^"]+)"\s*/?>`)
+	scriptTag     = regexp.MustCompile(`<script src="([^"]+)"></script>`)
+	srcAttr       = regexp.MustCompile(`(<img[^>]* src|<link rel="shortcut icon" href)="([^":]+)"`)
+	relativeLink  = regexp.MustCompile(`href="\./([^"]+)"`)
+)
+
+// inline はhtmlフォルダから読み込んでいるcss・スクリプト・画像をhtmlの中に埋め込みます。
+// 残ったhtmlフォルダへのリンクは公開先のURLにします。
+func inline(b []byte) ([]byte, error) {
+	var err error
+	read := func(name string) []byte {
+		c, e := ioutil.ReadFile(filepath.Join(*htmlFolder, filepath.FromSlash(name)))
+		if e != nil && err == nil {
+			err = e
+		}
+		return c
+	}
+	b = stylesheetTag.ReplaceAllFunc(b, func(m []byte) []byte {
+		name := stylesheetTag.FindSubmatch(m)[1]
+		return []byte("<style>\n" + string(read(string(name))) + "</style>")
+	})
+	b = scriptTag.ReplaceAllFunc(b, func(m []byte) []byte {
+		name := scriptTag.FindSubmatch(m)[1]
+		// </script> で終わってしまわないように
+		js := strings.Replace(string(read(string(name))), "</script", `<\/script`, -1)
+		return []byte("<script>\n" + js + "</script>")
+	})
+	b = srcAttr.ReplaceAllFunc(b, func(m []byte) []byte {
+		sm := srcAttr.FindSubmatch(m)
+		name := string(sm[2])
+		typ := mime.TypeByExtension(filepath.Ext(name))
+		if typ == "" {
+			typ = "application/octet-stream"
+		}
+		uri := "data:" + typ + ";base64," + base64.StdEncoding.EncodeToString(read(name))
+		return []byte(string(sm[1]) + `="` + uri + `"`)
+	})
+	b = relativeLink.ReplaceAll(b, []byte(`href="http://ashitani.jp/golangtips/$1"`))
+	return b, err
+}
@@ -0,0 +1,225 @@
+/*
+make_examples
+
+レジストリに登録された各Tipsから、pkg/tips_HOGE/example_test.go に
+Example_xxx() を生成します。HOGE_Xxx は Example_xxx()、HOGE_Xxx_go1_21 は
+Example_xxx_go121() になり、go doc ではパッケージの例として表示されます。
+生成したファイルはリポジトリに含めます。リポジトリのトップで
+
+	go generate
+
+または
+
+	go run ./cmd/make_examples
+
+のように実行し、その後 go test ./... で出力が変わっていないことを確認できます。
+Tipsを追加したり名前を変えたりして生成し直していなければ、テストが失敗します。
+
+// Output: はTipsを実際に何度か実行して作ります。
+時刻、乱数などを使うTipsは生成せず、理由をコメントに残します。
+ファイルシステムを使うTipsはサンドボックスの中で、入力を待つTipsは
+tips.SetInputs() で登録したスクリプトを流し込んで実行します。
+行の順番だけが変わる出力は // Unordered output: にします。
+*/
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+	_ "github.com/ashitani/golangtips/pkg/tips/all"
+)
+
+var runs = flag.Int("n", 20, "出力が毎回同じか確かめるために実行する回数")
+
+const header = "// Code generated by make_examples; DO NOT EDIT.\n\n"
+
+// 注記が日本時間で書かれているので、生成時もテスト時もtime.Localを揃える
+var jst = time.FixedZone("JST", 9*60*60)
+
+func main() {
+	flag.Parse()
+	time.Local = jst
+
+	for _, c := range tips.Categories() {
+		if len(c.Tips) == 0 {
+			continue
+		}
+		src, err := generate(c)
+		if err != nil {
+			log.Fatal(err)
+		}
+		filename := filepath.Join(filepath.Dir(c.Tips[0].File), "example_test.go")
+		if err := ioutil.WriteFile(filename, src, 0666); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func generate(c *tips.Category) ([]byte, error) {
+	var buf, skipped bytes.Buffer
+	sandboxed, scripted := false, false
+	names := map[string]string{}
+	for _, t := range tips.WithVariants(c.Tips) {
+		if t.Func == nil {
+			continue
+		}
+		name := exampleName(t.ID)
+		if id, ok := names[name]; ok {
+			return nil, fmt.Errorf("%s and %s both become %s", id, t.ID, name)
+		}
+		names[name] = t.ID
+		out, unordered, reason := record(t)
+		if reason != "" {
+			fmt.Fprintf(&skipped, "//   %s: %s\n", t.ID, reason)
+			continue
+		}
+
+		fmt.Fprintf(&buf, "\n// %s\nfunc %s() {\n", t.Title, name)
+		if t.Input != nil {
+			fmt.Fprintf(&buf, "\tdefer input.Feed(input.MustParse(%q))()\n", t.Input.String())
+			scripted = true
+		}
+		if t.Sandboxed() {
+			fmt.Fprintf(&buf, "\ttips.RunInSandbox(%s)\n", t.ID)
+			sandboxed = true
+		} else {
+			fmt.Fprintf(&buf, "\t%s()\n", t.ID)
+		}
+		if unordered {
+			buf.WriteString("\t// Unordered output:\n")
+		} else {
+			buf.WriteString("\t// Output:\n")
+		}
+		for _, l := range strings.Split(out, "\n") {
+			buf.WriteString(strings.TrimRight("\t// "+l, " ") + "\n")
+		}
+		buf.WriteString("}\n")
+	}
+
+	pkg := filepath.Base(filepath.Dir(c.Tips[0].File))
+	var src bytes.Buffer
+	src.WriteString(header)
+	fmt.Fprintf(&src, "package %s\n\n", pkg)
+	var imports []string
+	if sandboxed {
+		imports = append(imports, `"github.com/ashitani/golangtips/pkg/tips"`)
+		// 生成時と同じファイルがサンドボックスに置かれるように
+		for _, f := range tips.FixtureFiles() {
+			if dir := filepath.Dir(f); dir != filepath.Dir(c.Tips[0].File) {
+				imports = append(imports, `_ "github.com/ashitani/golangtips/`+filepath.ToSlash(dir)+`"`)
+			}
+		}
+	}
+	if scripted {
+		imports = append(imports, `"github.com/ashitani/golangtips/pkg/tips/input"`)
+	}
+	if len(imports) > 0 {
+		src.WriteString("import (\n\t\"time\"\n\n\t" + strings.Join(imports, "\n\t") + "\n)\n\n")
+	} else {
+		src.WriteString("import \"time\"\n\n")
+	}
+	if skipped.Len() > 0 {
+		src.WriteString("// 次のTipsはExampleにできないので生成していません。\n//\n")
+		skipped.WriteTo(&src)
+		src.WriteString("\n")
+	}
+	src.WriteString("func init() {\n\ttime.Local = time.FixedZone(\"JST\", 9*60*60)\n}\n")
+	buf.WriteTo(&src)
+	return format.Source(src.Bytes())
+}
+
+// exampleName はTipsのIDからExampleの関数名を作ります。
+// go doc がパッケージの例として扱うように、カテゴリ名を除いて先頭を小文字にします。
+//
+//	time_Make            -> Example_make
+//	slice_Reverse_go1_21 -> Example_reverse_go121
+func exampleName(id string) string {
+	name, version := id, ""
+	if i := strings.Index(id, "_go1_"); i >= 0 {
+		name, version = id[:i], "_go1"+id[i+len("_go1_"):]
+	}
+	if i := strings.Index(name, "_"); i >= 0 {
+		name = name[i+1:]
+	}
+	r, size := utf8.DecodeRuneInString(name)
+	return "Example_" + string(unicode.ToLower(r)) + name[size:] + version
+}
+
+// record はTipsを実行して // Output: に書く出力を返します。
+// 例にできない場合はその理由を返します。
+func record(t *tips.Tip) (out string, unordered bool, reason string) {
+	// ファイルシステムはサンドボックスの中で、入力は用意したスクリプトで使えるので除く
+	var es []tips.Effect
+	for _, e := range t.Effects() {
+		if e.Kind != "filesystem" && !(e.Kind == "stdin" && t.Input != nil) {
+			es = append(es, e)
+		}
+	}
+	if len(es) > 0 {
+		return "", false, tips.Reasons(es)
+	}
+
+	var outs []string
+	for i := 0; i < *runs; i++ {
+		var runErr error
+		o, err := tips.Capture(func() { runErr = t.RunUnattended() })
+		if err == nil {
+			err = runErr
+		}
+		if err != nil {
+			return "", false, err.Error()
+		}
+		outs = append(outs, strings.TrimRight(o, "\n"))
+	}
+	out = outs[0]
+	if out == "" {
+		return "", false, "出力がない"
+	}
+	if !utf8.ValidString(out) {
+		return "", false, "UTF-8でない出力はソースに書けない"
+	}
+	if !representable(out) {
+		return "", false, "行末の空白や連続した空行は // Output: で表せない"
+	}
+
+	for _, o := range outs[1:] {
+		if o == out {
+			continue
+		}
+		if sortLines(o) != sortLines(out) {
+			return "", false, "実行ごとに出力が変わる"
+		}
+		unordered = true
+	}
+	return out, unordered, ""
+}
+
+// // Output: のコメントからは行末の空白が落ち、連続した空行も詰められてしまうので、
+// そのような出力は書き写せません。
+func representable(s string) bool {
+	for _, l := range strings.Split(s, "\n") {
+		if strings.TrimRight(l, " \t") != l {
+			return false
+		}
+	}
+	return !strings.Contains(s, "\n\n\n") && !strings.HasPrefix(s, "\n")
+}
+
+func sortLines(s string) string {
+	ls := strings.Split(s, "\n")
+	sort.Strings(ls)
+	return strings.Join(ls, "\n")
+}
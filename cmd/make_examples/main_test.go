@@ -0,0 +1,76 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+)
+
+// 各Tipsが、リポジトリにある example_test.go でExampleになっているか、
+// 生成しなかった理由が書かれているかを確かめる。
+// Tipsを追加したり名前を変えたりして go generate し忘れると失敗する。
+func TestExamplesUpToDate(t *testing.T) {
+	for _, c := range tips.Categories() {
+		if len(c.Tips) == 0 {
+			continue
+		}
+		filename := filepath.Join("..", "..", filepath.Dir(c.Tips[0].File), "example_test.go")
+		f, err := parser.ParseFile(token.NewFileSet(), filename, nil, parser.ParseComments)
+		if err != nil {
+			t.Errorf("%v; run go generate", err)
+			continue
+		}
+		examples := map[string]bool{}
+		for _, d := range f.Decls {
+			if fn, ok := d.(*ast.FuncDecl); ok && strings.HasPrefix(fn.Name.Name, "Example") {
+				examples[fn.Name.Name] = true
+			}
+		}
+		skipped := map[string]bool{}
+		for _, cg := range f.Comments {
+			for _, l := range strings.Split(cg.Text(), "\n") {
+				if i := strings.Index(l, ":"); i >= 0 {
+					skipped[strings.TrimSpace(l[:i])] = true
+				}
+			}
+		}
+
+		ids := map[string]bool{}
+		for _, tip := range tips.WithVariants(c.Tips) {
+			if tip.Func == nil {
+				continue
+			}
+			name := exampleName(tip.ID)
+			ids[name] = true
+			if !examples[name] && !skipped[tip.ID] {
+				t.Errorf("%s: no %s for %s; run go generate", filename, name, tip.ID)
+			}
+		}
+		for name := range examples {
+			if !ids[name] {
+				t.Errorf("%s: %s has no tip; run go generate", filename, name)
+			}
+		}
+	}
+}
+
+func TestExampleName(t *testing.T) {
+	tests := []struct {
+		id, want string
+	}{
+		{"time_Make", "Example_make"},
+		{"slice_Reverse_go1_21", "Example_reverse_go121"},
+		{"num_CeilFloor_go1_10", "Example_ceilFloor_go110"},
+		{"string_ToI", "Example_toI"},
+	}
+	for _, tt := range tests {
+		if got := exampleName(tt.id); got != tt.want {
+			t.Errorf("exampleName(%q) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+/*
+make_programs
+
+各Tipsのコピペ用のコード(make_docがドキュメントに載せるもの)を
+examples/HOGE_hoge/main.go に書き出し、go/types で型検査します。
+リポジトリのトップで
+
+	go run ./cmd/make_programs
+
+のように実行します。ビルドできないコードがあれば位置とエラーを表示して終了コード1で終わります。
+書き出したファイルは個別に go run examples/string_Succ/main.go のように実行できます。
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+	_ "github.com/ashitani/golangtips/pkg/tips/all"
+)
+
+var outFolder = flag.String("o", "examples", "出力先")
+
+// go build ./... の対象にならないように build ignore を付ける
+const header = "// Code generated by make_programs; DO NOT EDIT.\n\n//go:build ignore\n\n"
+
+func main() {
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil)
+
+	failed := 0
+	for _, t := range tips.WithVariants(tips.All()) {
+		if t.Stub() {
+			continue
+		}
+		filename := filepath.Join(*outFolder, t.ID, "main.go")
+		src := []byte(t.Snippet())
+		if b, err := format.Source(src); err == nil {
+			src = b
+		}
+		if err := os.MkdirAll(filepath.Dir(filename), 0777); err != nil {
+			log.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filename, append([]byte(header), src...), 0666); err != nil {
+			log.Fatal(err)
+		}
+
+		for _, w := range t.ImportWarnings() {
+			log.Println("warning:", w)
+		}
+
+		errs := check(fset, imp, filename)
+		if len(errs) > 0 {
+			failed++
+			fmt.Printf("%s: %s (%s:%d)\n", t.ID, t.Title, t.File, t.Line)
+			for _, err := range errs {
+				fmt.Printf("    %v\n", err)
+			}
+		}
+	}
+	if failed > 0 {
+		log.Fatalf("%d programs do not build", failed)
+	}
+}
+
+// check はプログラムを型検査してエラーを返します。
+func check(fset *token.FileSet, imp types.Importer, filename string) []error {
+	f, err := parser.ParseFile(fset, filename, nil, 0)
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	conf := types.Config{
+		Importer: imp,
+		Error:    func(err error) { errs = append(errs, err) },
+	}
+	conf.Check("main", fset, []*ast.File{f}, nil)
+	return errs
+}
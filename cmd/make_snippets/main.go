@@ -0,0 +1,160 @@
+/*
+make_snippets
+
+各Tipsのコピペ用のコードを、エディタのスニペットに書き出します。リポジトリのトップで
+
+	go run ./cmd/make_snippets
+
+のように実行すると、snippets フォルダに次の2つができます。
+
+	golangtips.code-snippets  VS Code (ユーザースニペットか .vscode に置く)
+	go_golangtips.snippets    Vim UltiSnips (UltiSnipsのフォルダに置く)
+
+どちらも gt-string_Succ のように gt- とTipsのIDで展開され、説明にはTipsのタイトルが出ます。
+コード中の "test.txt" のようなファイル名はプレースホルダになっているので、展開後に書き換えられます。
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+	_ "github.com/ashitani/golangtips/pkg/tips/all"
+)
+
+var outFolder = flag.String("o", "snippets", "出力先")
+
+// プレースホルダにする文字列リテラル。サンドボックスに置いてあるファイルの名前です。
+var placeholders = []string{"test.txt", "foo.csv", "fmtTxt.txt"}
+
+// snippet はエディタに依存しないスニペット1つです。
+type snippet struct {
+	prefix      string
+	description string
+	code        string
+}
+
+func main() {
+	flag.Parse()
+
+	var ss []snippet
+	for _, t := range tips.WithVariants(tips.All()) {
+		if t.Stub() {
+			continue
+		}
+		src := []byte(t.Snippet())
+		if b, err := format.Source(src); err == nil {
+			src = b
+		}
+		ss = append(ss, snippet{prefix: "gt-" + t.ID, description: t.Title, code: string(src)})
+	}
+
+	if err := os.MkdirAll(*outFolder, 0777); err != nil {
+		log.Fatal(err)
+	}
+	vscode, err := vscodeSnippets(ss)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(*outFolder, "golangtips.code-snippets"), vscode, 0666); err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(*outFolder, "go_golangtips.snippets"), ultiSnips(ss), 0666); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// withPlaceholders はエスケープ済みのコード中の "test.txt" などを "${1:test.txt}" に置き換えます。
+// 同じファイル名の2回目からは $1 にして、最初の入力に合わせて変わるようにします。
+// VS CodeとUltiSnipsは同じ書き方です。
+func withPlaceholders(code string) string {
+	n := 0
+	for _, p := range placeholders {
+		lit := `"` + p + `"`
+		i := strings.Index(code, lit)
+		if i < 0 {
+			continue
+		}
+		n++
+		first := fmt.Sprintf(`"${%d:%s}"`, n, p)
+		code = code[:i] + first + strings.Replace(code[i+len(lit):], lit, fmt.Sprintf(`"$%d"`, n), -1)
+	}
+	return code
+}
+
+// VS Codeのスニペットの本文では \ と $ をエスケープする
+var vscodeEscaper = strings.NewReplacer(`\`, `\\`, `$`, `\$`)
+
+type vscodeSnippet struct {
+	Scope       string   `json:"scope"`
+	Prefix      string   `json:"prefix"`
+	Body        []string `json:"body"`
+	Description string   `json:"description"`
+}
+
+// vscodeSnippets は .code-snippets のJSONを目次の順に書き出します。
+func vscodeSnippets(ss []snippet) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+	for i, s := range ss {
+		code := withPlaceholders(vscodeEscaper.Replace(s.code))
+		v := vscodeSnippet{
+			Scope:       "go",
+			Prefix:      s.prefix,
+			Body:        strings.Split(strings.TrimRight(code, "\n"), "\n"),
+			Description: s.description,
+		}
+		key, err := json.Marshal(s.prefix)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.MarshalIndent(v, "\t", "\t")
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "\t%s: %s", key, val)
+		if i < len(ss)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes(), nil
+}
+
+// UltiSnipsのスニペットの本文では \ と $ と ` (Vim scriptなどの埋め込み) をエスケープする
+var ultiSnipsEscaper = strings.NewReplacer(`\`, `\\`, `$`, `\$`, "`", "\\`")
+
+// ultiSnips は UltiSnips の .snippets ファイルを書き出します。
+func ultiSnips(ss []snippet) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("# Code generated by make_snippets; DO NOT EDIT.\n\n")
+	buf.WriteString("priority -50\n")
+	for _, s := range ss {
+		// 説明は "..." で囲むが、" を含むなら含まない文字で囲む
+		q := `"`
+		for _, c := range []string{`"`, "!", "|", "'"} {
+			if !strings.Contains(s.description, c) {
+				q = c
+				break
+			}
+		}
+		fmt.Fprintf(&buf, "\nsnippet %s %s%s%s\n", s.prefix, q, s.description, q)
+		buf.WriteString(withPlaceholders(ultiSnipsEscaper.Replace(s.code)))
+		if !strings.HasSuffix(s.code, "\n") {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("endsnippet\n")
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+	"github.com/ashitani/golangtips/pkg/tips/cellwidth"
+)
+
+var cmdCoverage = &command{
+	name:  "coverage",
+	usage: "coverage [-md] [category...]",
+	short: "逆引きRubyの目次のうち、移植済み・見出しのみ・未着手を表示する",
+	run:   runCoverage,
+}
+
+func runCoverage(args []string) error {
+	fs := flag.NewFlagSet("coverage", flag.ExitOnError)
+	md := fs.Bool("md", false, "markdownで出力する")
+	fs.Parse(args)
+
+	cs := tips.RubyCoverage()
+	if fs.NArg() > 0 {
+		var sel []*tips.Coverage
+		for _, name := range fs.Args() {
+			c, ok := lookupCoverage(cs, name)
+			if !ok {
+				return fmt.Errorf("unknown category %q", name)
+			}
+			sel = append(sel, c)
+		}
+		cs = sel
+	}
+
+	if *md {
+		writeCoverageMarkdown(os.Stdout, cs)
+		return nil
+	}
+	writeCoverageText(os.Stdout, cs)
+	return nil
+}
+
+// 見出しの列を表示幅で揃えて書き出す。tabwriterはルーン数で数えるので、全角文字があるとずれる
+func writeCoverageText(w io.Writer, cs []*tips.Coverage) {
+	width := 0
+	for _, c := range cs {
+		for _, e := range c.Entries {
+			if n := cellwidth.Width(e.Title); n > width {
+				width = n
+			}
+		}
+		for _, t := range c.Extra {
+			if n := cellwidth.Width(t.Title); n > width {
+				width = n
+			}
+		}
+	}
+	for i, c := range cs {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s (%s)  ported %d, stubbed %d, missing %d\n", c.Section.Title, sectionCategory(c.Section),
+			c.Count("ported"), c.Count("stubbed"), c.Count("missing"))
+		for _, e := range c.Entries {
+			id := ""
+			if e.Tip != nil {
+				id = e.Tip.ID
+			}
+			writeCoverageLine(w, e.Status, e.Title, id, width)
+		}
+		for _, t := range c.Extra {
+			writeCoverageLine(w, "extra", t.Title, t.ID, width)
+		}
+	}
+}
+
+func writeCoverageLine(w io.Writer, status, title, id string, width int) {
+	if id == "" {
+		fmt.Fprintf(w, "  %-7s  %s\n", status, title)
+		return
+	}
+	fmt.Fprintf(w, "  %-7s  %s  %s\n", status, cellwidth.Ljust(title, width, " "), id)
+}
+
+// 逆引きGolangにカテゴリがない節は、カテゴリ名の代わりに "-" と書く
+func sectionCategory(s *tips.RubySection) string {
+	if s.Category == "" {
+		return "-"
+	}
+	return s.Category
+}
+
+// markdownのアンカー。カテゴリがない節は節の名前を使う
+func sectionAnchor(s *tips.RubySection) string {
+	if s.Category == "" {
+		return s.Title
+	}
+	return s.Category
+}
+
+func lookupCoverage(cs []*tips.Coverage, name string) (*tips.Coverage, bool) {
+	for _, c := range cs {
+		if c.Section.Category == name || "tips_"+c.Section.Category == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// 表で集計を、節ごとのチェックリストで見出しの状況を書き出す
+func writeCoverageMarkdown(w io.Writer, cs []*tips.Coverage) {
+	fmt.Fprintln(w, "# 逆引きRubyとの対応状況")
+	fmt.Fprintln(w)
+	rows := [][]string{{"カテゴリ", "移植済み", "見出しのみ", "未着手"}}
+	for _, c := range cs {
+		rows = append(rows, []string{
+			fmt.Sprintf("[%s](#%s)", c.Section.Title, sectionAnchor(c.Section)),
+			fmt.Sprint(c.Count("ported")), fmt.Sprint(c.Count("stubbed")), fmt.Sprint(c.Count("missing")),
+		})
+	}
+	writeMarkdownTable(w, rows)
+	for _, c := range cs {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "## <a name=\"%s\"> %s (%s)</a>\n", sectionAnchor(c.Section), c.Section.Title, sectionCategory(c.Section))
+		fmt.Fprintln(w)
+		for _, e := range c.Entries {
+			switch e.Status {
+			case "ported":
+				fmt.Fprintf(w, "- [x] %s (`%s`)\n", e.Title, e.Tip.ID)
+			case "stubbed":
+				fmt.Fprintf(w, "- [ ] %s (見出しのみ)\n", e.Title)
+			default:
+				fmt.Fprintf(w, "- [ ] %s\n", e.Title)
+			}
+		}
+		if len(c.Extra) > 0 {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "逆引きRubyにないTips:")
+			fmt.Fprintln(w)
+			for _, t := range c.Extra {
+				fmt.Fprintf(w, "- %s (`%s`)\n", t.Title, t.ID)
+			}
+		}
+	}
+}
+
+// 1列目を左寄せ、残りを右寄せにして、列を表示幅で揃えた表を書き出す
+func writeMarkdownTable(w io.Writer, rows [][]string) {
+	widths := make([]int, len(rows[0]))
+	for _, r := range rows {
+		for i, cell := range r {
+			if n := cellwidth.Width(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	for j, r := range rows {
+		for i, cell := range r {
+			if i == 0 {
+				fmt.Fprintf(w, "| %s ", cellwidth.Ljust(cell, widths[i], " "))
+			} else {
+				fmt.Fprintf(w, "| %s ", cellwidth.Rjust(cell, widths[i], " "))
+			}
+		}
+		fmt.Fprintln(w, "|")
+		if j == 0 {
+			for i, n := range widths {
+				if i == 0 {
+					fmt.Fprintf(w, "|%s", strings.Repeat("-", n+2))
+				} else {
+					fmt.Fprintf(w, "|%s:", strings.Repeat("-", n+1))
+				}
+			}
+			fmt.Fprintln(w, "|")
+		}
+	}
+}
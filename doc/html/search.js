@@ -0,0 +1,121 @@
+// 逆引きGolang の検索ボックス
+//
+// make_doc が書き出した search_index.json を読んで、ページ内だけで検索します。
+// トークンの分け方は pkg/tips/index.go の Tokenize() と同じです。
+(function() {
+    var index = null;
+    var maxResults = 20;
+
+    function isWord(c) {
+        return /[0-9A-Za-z_]/.test(c);
+    }
+
+    function isLetter(c) {
+        return c.charCodeAt(0) >= 0x80 && /[\p{L}\p{N}]/u.test(c);
+    }
+
+    function tokenize(s) {
+        var toks = [];
+        var cs = Array.from(s.toLowerCase());
+        var i = 0;
+        while (i < cs.length) {
+            var j = i;
+            if (isWord(cs[i])) {
+                while (j < cs.length && isWord(cs[j])) j++;
+                var w = cs.slice(i, j).join("");
+                toks.push(w);
+                if (w.indexOf("_") >= 0) {
+                    w.split("_").forEach(function(p) {
+                        if (p !== "") toks.push(p);
+                    });
+                }
+            } else if (isLetter(cs[i])) {
+                while (j < cs.length && isLetter(cs[j])) j++;
+                if (j - i == 1) toks.push(cs[i]);
+                for (var k = i; k + 1 < j; k++) toks.push(cs[k] + cs[k + 1]);
+            } else {
+                j = i + 1;
+            }
+            i = j;
+        }
+        return toks;
+    }
+
+    // 1つのトークンに当てはまるTipsの添字の集合
+    // 英数字は前方一致、日本語1文字はその文字を含むbigramにも当てはめる
+    function lookup(tok) {
+        var docs = {};
+        var add = function(ds) {
+            ds.forEach(function(d) { docs[d] = true; });
+        };
+        if (isWord(tok.charAt(0))) {
+            Object.keys(index.tokens).forEach(function(k) {
+                if (k.lastIndexOf(tok, 0) === 0) add(index.tokens[k]);
+            });
+        } else if (Array.from(tok).length == 1) {
+            Object.keys(index.tokens).forEach(function(k) {
+                if (k.indexOf(tok) >= 0) add(index.tokens[k]);
+            });
+        } else if (index.tokens[tok]) {
+            add(index.tokens[tok]);
+        }
+        return docs;
+    }
+
+    function search(q) {
+        var toks = tokenize(q);
+        if (toks.length == 0) return [];
+        var hits = null;
+        toks.forEach(function(tok) {
+            var docs = lookup(tok);
+            if (hits === null) {
+                hits = docs;
+                return;
+            }
+            Object.keys(hits).forEach(function(d) {
+                if (!docs[d]) delete hits[d];
+            });
+        });
+        return Object.keys(hits).map(Number).sort(function(a, b) { return a - b; });
+    }
+
+    function show(q) {
+        var ul = document.getElementById("search_results");
+        ul.innerHTML = "";
+        if (index === null) return;
+        var hits = search(q);
+        hits.slice(0, maxResults).forEach(function(d) {
+            var doc = index.docs[d];
+            var a = document.createElement("a");
+            a.href = doc.url;
+            a.textContent = doc.title;
+            a.title = doc.category + " / " + doc.id;
+            var li = document.createElement("li");
+            li.appendChild(a);
+            ul.appendChild(li);
+        });
+        if (hits.length > maxResults) {
+            var li = document.createElement("li");
+            li.textContent = "ほか" + (hits.length - maxResults) + "件";
+            ul.appendChild(li);
+        }
+    }
+
+    function load(box) {
+        var xhr = new XMLHttpRequest();
+        xhr.open("GET", "./search_index.json");
+        xhr.onload = function() {
+            if (xhr.status !== 200 && xhr.status !== 0) return;
+            index = JSON.parse(xhr.responseText);
+            show(box.value);
+        };
+        xhr.send();
+    }
+
+    window.addEventListener("DOMContentLoaded", function() {
+        var box = document.getElementById("search_box");
+        if (!box) return;
+        box.addEventListener("input", function() { show(box.value); });
+        load(box);
+    });
+})();
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+)
+
+var cmdLint = &command{
+	name:  "lint",
+	usage: "lint [-nowarn] [file...]",
+	short: "Tipsのソースが区切りや登録の決まりどおりか確かめる",
+	run:   runLint,
+}
+
+func runLint(args []string) error {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	nowarn := fs.Bool("nowarn", false, "説明がないなどの警告は表示しない")
+	fs.Parse(args)
+
+	files := fs.Args()
+	if len(files) == 0 {
+		// リポジトリのトップで実行する
+		files, _ = filepath.Glob(filepath.Join("pkg", "tips_*", "tips_*.go"))
+		if len(files) == 0 {
+			return errors.New("no pkg/tips_*/tips_*.go; run in the top of the repository or give files")
+		}
+	}
+
+	ds, err := tips.Lint(files)
+	if err != nil {
+		return err
+	}
+	errs := 0
+	for _, d := range ds {
+		if !d.Warning {
+			errs++
+		} else if *nowarn {
+			continue
+		}
+		fmt.Println(d)
+	}
+	if errs > 0 {
+		return fmt.Errorf("%d problems", errs)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+)
+
+var cmdList = &command{
+	name:  "list",
+	usage: "list [category...]",
+	short: "カテゴリとTipsの一覧を表示する",
+	run:   runList,
+}
+
+func runList(args []string) error {
+	cs := tips.Categories()
+	if len(args) > 0 {
+		cs = nil
+		for _, name := range args {
+			c, ok := tips.LookupCategory(name)
+			if !ok {
+				return fmt.Errorf("unknown category %q", name)
+			}
+			cs = append(cs, c)
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	for i, c := range cs {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s (%s)\n", c.Title, c.Name)
+		for _, t := range c.Tips {
+			if t.ID != "" {
+				printTip(w, t)
+			}
+		}
+	}
+	return w.Flush()
+}
+
+// printTip は一覧の1行を書き出します。
+func printTip(w io.Writer, t *tips.Tip) {
+	fmt.Fprintf(w, "  %s\t%s\n", t.ID, t.Title)
+}
@@ -4,24 +4,74 @@
 [逆引きRuby](http://www.namaraii.com/rubytips)の内容をGolang化したものです。
 [こちら](http://ashitani.jp/golangtips)で公開しています。
 
+	golangtips list [category...]       Tipsの一覧
+	golangtips show <id>                説明とコピペ用のコード
+	golangtips run <id|category>...     Tipsを実行
+	golangtips search <text>...         タイトルと説明を検索
+	golangtips ruby [method...]         Rubyのメソッド名 (例: Array#assoc) からTipsを探す
+	golangtips coverage [-md] [category...]  逆引きRubyの目次に対する移植の状況
+	golangtips bench [id|category...]   ベンチマークをとり、ns/opとallocs/opの表を表示
+	golangtips verify [id|category...]  実行結果を // => の注記と突き合わせる
+	golangtips lint [-nowarn] [file...] Tipsのソースが区切りや登録の決まりどおりか確かめる
+	golangtips serve [-addr host:port]  ドキュメントをlocalhostで表示し、ページからTipsを実行
+	golangtips nkf [-g] [-w|-s|-e|-j] [file...]  日本語の文字コードを判定・変換する (nkfの一部)
 */
 
 package main
 
 import (
-	. "./pkg/tips_map"
-	. "./pkg/tips_num"
-	. "./pkg/tips_regexp"
-	. "./pkg/tips_slice"
-	. "./pkg/tips_string"
-	. "./pkg/tips_time"
+	"fmt"
+	"os"
+
+	_ "github.com/ashitani/golangtips/pkg/tips/all"
 )
 
+//go:generate go run ./cmd/make_examples
+
+// command はサブコマンドです。
+type command struct {
+	name  string
+	usage string
+	short string
+	run   func(args []string) error
+}
+
+var commands = []*command{
+	cmdList,
+	cmdShow,
+	cmdRun,
+	cmdSearch,
+	cmdRuby,
+	cmdCoverage,
+	cmdBench,
+	cmdVerify,
+	cmdLint,
+	cmdServe,
+	cmdNkf,
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: golangtips <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-32s %s\n", c.usage, c.short)
+	}
+	os.Exit(2)
+}
+
 func main() {
-	Tips_string()
-	Tips_num()
-	Tips_time()
-	Tips_slice()
-	Tips_map()
-	Tips_regexp()
+	if len(os.Args) < 2 {
+		usage()
+	}
+	for _, c := range commands {
+		if c.name != os.Args[1] {
+			continue
+		}
+		if err := c.run(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "golangtips:", err)
+			os.Exit(1)
+		}
+		return
+	}
+	usage()
 }
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"golang.org/x/text/transform"
+
+	"github.com/ashitani/golangtips/pkg/tips/kconv"
+)
+
+var cmdNkf = &command{
+	name:  "nkf",
+	usage: "nkf [-g] [-w|-s|-e|-j] [file...]",
+	short: "日本語の文字コードを判定・変換する (nkfの一部)",
+	run:   runNkf,
+}
+
+func runNkf(args []string) error {
+	fs := flag.NewFlagSet("nkf", flag.ExitOnError)
+	guess := fs.Bool("g", false, "変換せず、判定した文字コードと改行を表示する")
+
+	// 出力の文字コード。指定しなければUTF-8
+	outputs := []struct {
+		flag *bool
+		enc  kconv.Encoding
+	}{
+		{fs.Bool("w", false, "UTF-8で出力する"), kconv.UTF8},
+		{fs.Bool("w8", false, "BOMを付けたUTF-8で出力する"), kconv.UTF8BOM},
+		{fs.Bool("s", false, "Shift_JISで出力する"), kconv.ShiftJIS},
+		{fs.Bool("e", false, "EUC-JPで出力する"), kconv.EUCJP},
+		{fs.Bool("j", false, "ISO-2022-JPで出力する"), kconv.ISO2022JP},
+	}
+	// 入力の文字コード。指定しなければ判定する
+	inputs := []struct {
+		flag *bool
+		enc  kconv.Encoding
+	}{
+		{fs.Bool("W", false, "入力をUTF-8として読む"), kconv.UTF8},
+		{fs.Bool("S", false, "入力をShift_JISとして読む"), kconv.ShiftJIS},
+		{fs.Bool("E", false, "入力をEUC-JPとして読む"), kconv.EUCJP},
+		{fs.Bool("J", false, "入力をISO-2022-JPとして読む"), kconv.ISO2022JP},
+	}
+	// 改行
+	eols := []struct {
+		flag *bool
+		eol  string
+	}{
+		{fs.Bool("Lu", false, "改行をLFにする"), "\n"},
+		{fs.Bool("Lw", false, "改行をCRLFにする"), "\r\n"},
+		{fs.Bool("Lm", false, "改行をCRにする"), "\r"},
+	}
+	fs.Parse(args)
+
+	files := fs.Args()
+	if *guess {
+		return guessFiles(files)
+	}
+	to, from, eol := kconv.UTF8, kconv.Auto, ""
+	for _, o := range outputs {
+		if *o.flag {
+			to = o.enc
+		}
+	}
+	for _, i := range inputs {
+		if *i.flag {
+			from = i.enc
+		}
+	}
+	for _, e := range eols {
+		if *e.flag {
+			eol = e.eol
+		}
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	if len(files) == 0 {
+		return nkf(out, os.Stdin, from, to, eol)
+	}
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		err = nkf(out, f, from, to, eol)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// r を from から to に変換して w に書く。eol が空でなければ改行もそろえる
+func nkf(w io.Writer, r io.Reader, from, to kconv.Encoding, eol string) error {
+	r = kconv.NewReader(r, from)
+	if eol != "" {
+		r = transform.NewReader(r, kconv.Newline(eol))
+	}
+	kw := kconv.NewWriter(w, to)
+	if _, err := io.Copy(kw, r); err != nil {
+		return err
+	}
+	return kw.Close()
+}
+
+// nkf -g のように Shift_JIS (CRLF) の形で表示する。複数のファイルならファイル名も付ける
+func guessFiles(files []string) error {
+	show := func(prefix string, b []byte) {
+		s := kconv.Guess(b).String()
+		if nl := kconv.NewlineOf(b); nl != "" {
+			s += " (" + nl + ")"
+		}
+		fmt.Println(prefix + s)
+	}
+	if len(files) == 0 {
+		b, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		show("", b)
+		return nil
+	}
+	failed := 0
+	for _, name := range files {
+		b, err := ioutil.ReadFile(name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			failed++
+			continue
+		}
+		if len(files) > 1 {
+			show(name+": ", b)
+		} else {
+			show("", b)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d files could not be read", failed)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+/*
+全カテゴリのTipsを登録します。
+
+	import _ "github.com/ashitani/golangtips/pkg/tips/all"
+*/
+
+package all
+
+import (
+	_ "github.com/ashitani/golangtips/pkg/tips_dir"
+	_ "github.com/ashitani/golangtips/pkg/tips_file"
+	_ "github.com/ashitani/golangtips/pkg/tips_goroutine"
+	_ "github.com/ashitani/golangtips/pkg/tips_map"
+	_ "github.com/ashitani/golangtips/pkg/tips_num"
+	_ "github.com/ashitani/golangtips/pkg/tips_regexp"
+	_ "github.com/ashitani/golangtips/pkg/tips_slice"
+	_ "github.com/ashitani/golangtips/pkg/tips_string"
+	_ "github.com/ashitani/golangtips/pkg/tips_time"
+)
@@ -0,0 +1,110 @@
+package tips
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// SetBenchmarks はTipsの中心になる補助関数のベンチマークを登録します。
+// benches はIDから補助関数を1回呼ぶ関数への対応で、Register() の後に呼びます。
+// 登録のないTipsは、Benchmark() がTipsの関数全体を測ります。
+func SetBenchmarks(benches map[string]func()) {
+	for id, f := range benches {
+		t, ok := byID[id]
+		if !ok {
+			panic(fmt.Sprintf("tips: %s is not registered", id))
+		}
+		t.bench = f
+	}
+}
+
+// BenchResult はTips 1つのベンチマークの結果です。
+// NsPerOp(), AllocsPerOp(), AllocedBytesPerOp() は testing.BenchmarkResult のものです。
+type BenchResult struct {
+	Tip    *Tip
+	Helper bool   // SetBenchmarks() で登録した補助関数を測ったか
+	Skip   string // 測らなかった理由。測ったなら空
+	testing.BenchmarkResult
+}
+
+// Benchmark はTipsを testing.Benchmark() で、合計が d ほどかかるまで繰り返して測ります。測っている間の標準出力は捨てます。
+//
+// ファイルシステムを使うTipsは、ファイルを作ったり消したりして繰り返せないことがあるので、
+// 補助関数のベンチマークが登録されている場合だけ、サンドボックスの中で測ります。
+// 入力を待つ・時間待ちをする・外部コマンドを実行するTipsは測りません。
+func Benchmark(t *Tip, d time.Duration) *BenchResult {
+	r := &BenchResult{Tip: t, Helper: t.bench != nil}
+	if t.Func == nil {
+		r.Skip = "コードがない"
+		return r
+	}
+
+	var es []Effect
+	for _, e := range t.Effects() {
+		switch {
+		case e.Kind == "filesystem" && r.Helper:
+		case e.Kind == "clock" || e.Kind == "random":
+		default:
+			es = append(es, e)
+		}
+	}
+	if len(es) > 0 {
+		r.Skip = Reasons(es)
+		return r
+	}
+
+	f := t.bench
+	if f == nil {
+		f = t.Func
+	}
+	run := func() {
+		discardStdout(func() { r.measure(f, d) })
+	}
+	if t.Sandboxed() {
+		if err := RunInSandbox(run); err != nil {
+			r.Skip = err.Error()
+		}
+	} else {
+		run()
+	}
+	return r
+}
+
+// measure は testing.Benchmark() で f を測ります。合計の時間の目安は d です。
+func (r *BenchResult) measure(f func(), d time.Duration) {
+	setBenchtime(d)
+	r.BenchmarkResult = testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			f()
+		}
+	})
+}
+
+var benchInit sync.Once
+
+// setBenchtime は testing.Benchmark() が使う -test.benchtime を d にします。
+// testing のフラグは testing.Init() で登録されるので、go test の外では先に一度呼びます。
+func setBenchtime(d time.Duration) {
+	benchInit.Do(testing.Init)
+	flag.Set("test.benchtime", d.String())
+}
+
+func discardStdout(f func()) {
+	null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		f()
+		return
+	}
+	stdout := os.Stdout
+	os.Stdout = null
+	defer func() {
+		os.Stdout = stdout
+		null.Close()
+	}()
+	f()
+}
@@ -0,0 +1,128 @@
+/*
+端末や等幅フォントでの表示幅(セル数)で文字列を数え、左詰・右詰・中央寄せ・切り詰めをします。
+
+	cellwidth.Width("Go言語")          // => 6
+	cellwidth.Ljust("日本語", 10, " ") // => "日本語    "
+
+全角文字(East Asian WideとFullwidth)は2、結合文字や制御文字は0、それ以外は1と数えます。
+○や①、ギリシャ文字などの「曖昧な幅」の文字は環境によって1にも2にもなるので、
+Counter の Ambiguous で決めます。パッケージの関数は1として数えます。
+*/
+
+package cellwidth
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// Counter は表示幅の数え方です。ゼロ値は曖昧な幅の文字を1と数えます。
+type Counter struct {
+	Ambiguous int // 曖昧な幅の文字の幅。2以外は1とみなす
+}
+
+var std Counter
+
+// Width は std.Width(s) です。
+func Width(s string) int { return std.Width(s) }
+
+// Ljust は std.Ljust(s, w, pad) です。
+func Ljust(s string, w int, pad string) string { return std.Ljust(s, w, pad) }
+
+// Rjust は std.Rjust(s, w, pad) です。
+func Rjust(s string, w int, pad string) string { return std.Rjust(s, w, pad) }
+
+// Center は std.Center(s, w, pad) です。
+func Center(s string, w int, pad string) string { return std.Center(s, w, pad) }
+
+// Truncate は std.Truncate(s, w, tail) です。
+func Truncate(s string, w int, tail string) string { return std.Truncate(s, w, tail) }
+
+// RuneWidth は1文字の表示幅を返します。
+func (c Counter) RuneWidth(r rune) int {
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf, unicode.Cc) {
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	case width.EastAsianAmbiguous:
+		if c.Ambiguous == 2 {
+			return 2
+		}
+	}
+	return 1
+}
+
+// Width は文字列の表示幅を返します。
+func (c Counter) Width(s string) int {
+	n := 0
+	for _, r := range s {
+		n += c.RuneWidth(r)
+	}
+	return n
+}
+
+// Ljust は s の右を pad で埋めて表示幅を w にします。s が w より広ければそのままです。
+// 入りきらない全角文字は空白にし、幅0の pad は空白にします。
+func (c Counter) Ljust(s string, w int, pad string) string {
+	return s + c.padding(pad, w-c.Width(s))
+}
+
+// Rjust は s の左を pad で埋めて表示幅を w にします。
+func (c Counter) Rjust(s string, w int, pad string) string {
+	return c.padding(pad, w-c.Width(s)) + s
+}
+
+// Center は s の両側を pad で埋めて表示幅を w にします。割り切れなければ右を多くします。
+func (c Counter) Center(s string, w int, pad string) string {
+	n := w - c.Width(s)
+	if n <= 0 {
+		return s
+	}
+	return c.padding(pad, n/2) + s + c.padding(pad, n-n/2)
+}
+
+// Truncate は s が w より広ければ、末尾に tail を付けて表示幅を w 以下に切り詰めます。
+// tail も入らなければ tail を切り詰めたものを返します。負の w は0とみなします。
+func (c Counter) Truncate(s string, w int, tail string) string {
+	if w < 0 {
+		w = 0
+	}
+	if c.Width(s) <= w {
+		return s
+	}
+	n := w - c.Width(tail)
+	if n < 0 {
+		return c.Truncate(tail, w, "")
+	}
+	for i, r := range s {
+		rw := c.RuneWidth(r)
+		if rw > n {
+			return s[:i] + tail
+		}
+		n -= rw
+	}
+	return s + tail
+}
+
+// pad を繰り返して表示幅 n の文字列を作る。幅0の pad は空白にする
+func (c Counter) padding(pad string, n int) string {
+	if c.Width(pad) == 0 {
+		pad = " "
+	}
+	var b strings.Builder
+	for n > 0 {
+		for _, r := range pad {
+			rw := c.RuneWidth(r)
+			if rw > n {
+				return b.String() + strings.Repeat(" ", n)
+			}
+			b.WriteRune(r)
+			n -= rw
+		}
+	}
+	return b.String()
+}
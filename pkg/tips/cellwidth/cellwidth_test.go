@@ -0,0 +1,81 @@
+package cellwidth
+
+import "testing"
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		in        string
+		want      int
+		ambiguous int // Ambiguous: 2 のとき
+	}{
+		{"", 0, 0},
+		{"abc", 3, 3},
+		{"Go言語", 6, 6},
+		{"ｱｲｳ", 3, 3},
+		{"ＡＢＣ", 6, 6},
+		{"が", 2, 2},
+		{"が", 2, 2},
+		{"é", 1, 1},
+		{"a\tb", 2, 2},
+		{"①②③", 3, 6},
+		{"αβ", 2, 4},
+	}
+	for _, tt := range tests {
+		if got := Width(tt.in); got != tt.want {
+			t.Errorf("Width(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+		if got := (Counter{Ambiguous: 2}).Width(tt.in); got != tt.ambiguous {
+			t.Errorf("Counter{Ambiguous: 2}.Width(%q) = %d, want %d", tt.in, got, tt.ambiguous)
+		}
+	}
+}
+
+func TestJust(t *testing.T) {
+	tests := []struct {
+		f    func(string, int, string) string
+		name string
+		s    string
+		w    int
+		pad  string
+		want string
+	}{
+		{Ljust, "Ljust", "日本語", 10, " ", "日本語    "},
+		{Rjust, "Rjust", "日本語", 10, " ", "    日本語"},
+		{Center, "Center", "日本語", 20, "*", "*******日本語*******"},
+		{Center, "Center", "abc", 8, "12", "12abc121"},
+		{Center, "Center", "Go", 9, "・", "・ Go・・"},
+		{Ljust, "Ljust", "日本語", 3, " ", "日本語"},
+		{Rjust, "Rjust", "abc", -1, " ", "abc"},
+		{Center, "Center", "abc", -5, " ", "abc"},
+		{Ljust, "Ljust", "a", 3, "́", "a  "},
+		{Ljust, "Ljust", "a", 4, "漢", "a漢 "},
+	}
+	for _, tt := range tests {
+		if got := tt.f(tt.s, tt.w, tt.pad); got != tt.want {
+			t.Errorf("%s(%q, %d, %q) = %q, want %q", tt.name, tt.s, tt.w, tt.pad, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s, tail string
+		w       int
+		want    string
+	}{
+		{"逆引きGolangのTips集", "...", 12, "逆引きGol..."},
+		{"日本語", "", 5, "日本"},
+		{"日本語", "", 6, "日本語"},
+		{"日本語", "…", 4, "日…"},
+		{"abcdef", "...", 2, ".."},
+		{"abcdef", "...", 0, ""},
+		{"abcdef", "...", -1, ""},
+		{"abcdef", "", -10, ""},
+		{"", "...", -1, ""},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.s, tt.w, tt.tail); got != tt.want {
+			t.Errorf("Truncate(%q, %d, %q) = %q, want %q", tt.s, tt.w, tt.tail, got, tt.want)
+		}
+	}
+}
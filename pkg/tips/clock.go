@@ -0,0 +1,222 @@
+package tips
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tipsの関数は現在時刻を tips.Clock から、乱数を tips.Rand から取ります。
+// 普段は time.Now() や math/rand と同じですが、実行のときに時刻を止めたりシードを決めたりできます。
+// /etc を指すパスは tips.Etc() を通して、サンドボックスの etc に移せるようにします。
+//
+// ドキュメントとコピペ用のコード(Snippet)では、Parse() がこれらを time.Now() や rand.Intn()、
+// "/etc" のような普通のGoに戻すので、読む人には tips パッケージは見えません。
+
+// Clock はTipsが使う時計です。
+var Clock = &clock{}
+
+type clock struct {
+	mu  sync.Mutex
+	now *time.Time // nilでなければこの時刻で止める
+}
+
+// Now は time.Now() の代わりです。
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.now != nil {
+		return *c.now
+	}
+	return time.Now()
+}
+
+// Since は time.Since() の代わりです。
+func (c *clock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Rand はTipsが使う乱数です。math/rand のパッケージレベルの関数と同じ名前のメソッドを持ちます。
+var Rand = &random{}
+
+type random struct {
+	mu    sync.Mutex
+	r     *rand.Rand // nilなら math/rand のパッケージレベルの関数を使う
+	fixed *int64     // nilでなければ Seed() の引数の代わりにこのシードを使う
+}
+
+// Seed は rand.Seed() の代わりです。Go 1.24からの rand.Seed() と違い、シードは実際に効きます。
+func (r *random) Seed(seed int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fixed != nil {
+		seed = *r.fixed
+	}
+	r.r = rand.New(rand.NewSource(seed))
+}
+
+// Int は rand.Int() の代わりです。
+func (r *random) Int() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.r == nil {
+		return rand.Int()
+	}
+	return r.r.Int()
+}
+
+// Intn は rand.Intn() の代わりです。
+func (r *random) Intn(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.r == nil {
+		return rand.Intn(n)
+	}
+	return r.r.Intn(n)
+}
+
+// Float32 は rand.Float32() の代わりです。
+func (r *random) Float32() float32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.r == nil {
+		return rand.Float32()
+	}
+	return r.r.Float32()
+}
+
+// Float64 は rand.Float64() の代わりです。
+func (r *random) Float64() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.r == nil {
+		return rand.Float64()
+	}
+	return r.r.Float64()
+}
+
+// Perm は rand.Perm() の代わりです。
+func (r *random) Perm(n int) []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.r == nil {
+		return rand.Perm(n)
+	}
+	return r.r.Perm(n)
+}
+
+// Shuffle は rand.Shuffle() の代わりです。
+func (r *random) Shuffle(n int, swap func(i, j int)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.r == nil {
+		rand.Shuffle(n, swap)
+		return
+	}
+	r.r.Shuffle(n, swap)
+}
+
+// サンドボックスの etc。空なら /etc のまま
+var etcDir string
+
+// Etc は "/etc" で始まるパス p を、サンドボックスの中で実行しているならその etc の下のパスにします。
+func Etc(p string) string {
+	if etcDir == "" || !isEtc(p) {
+		return p
+	}
+	return etcDir + strings.TrimPrefix(p, "/etc")
+}
+
+// Fixed は実行のときに止める時刻と決めるシードです。
+type Fixed struct {
+	Now  *time.Time // nilでなければ tips.Clock をこの時刻で止める
+	Seed *int64     // nilでなければ tips.Rand のシードをこの値にする
+}
+
+// FixedFromEnv は環境変数 GOLANGTIPS_NOW (RFC 3339の時刻) と GOLANGTIPS_SEED (整数) から
+// Fixed を作ります。GOLANGTIPS_SEED がなく GOLANGTIPS_NOW がある場合は、その時刻がシードです。
+// どちらもなければ nil を返します。
+func FixedFromEnv() (*Fixed, error) {
+	fx := &Fixed{}
+	if s := os.Getenv("GOLANGTIPS_NOW"); s != "" {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, fmt.Errorf("GOLANGTIPS_NOW: %v", err)
+		}
+		fx.Now = &t
+	}
+	if s := os.Getenv("GOLANGTIPS_SEED"); s != "" {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("GOLANGTIPS_SEED: %v", err)
+		}
+		fx.Seed = &n
+	} else if fx.Now != nil {
+		n := fx.Now.UnixNano()
+		fx.Seed = &n
+	}
+	if fx.Now == nil && fx.Seed == nil {
+		return nil, nil
+	}
+	return fx, nil
+}
+
+// set は tips.Clock と tips.Rand を fx のとおりにして、元に戻す関数を返します。
+//
+// 時刻を止めるときは、time.Local もその時刻のタイムゾーンにします。
+// GOLANGTIPS_NOW に +09:00 と書けば、どのマシンでも +0900 で表示されます。
+func (fx *Fixed) set() (restore func()) {
+	if fx == nil {
+		return func() {}
+	}
+	local := time.Local
+	Clock.mu.Lock()
+	now := Clock.now
+	if fx.Now != nil {
+		name, offset := fx.Now.Zone()
+		time.Local = time.FixedZone(name, offset)
+		t := fx.Now.In(time.Local)
+		Clock.now = &t
+	}
+	Clock.mu.Unlock()
+
+	Rand.mu.Lock()
+	r, fixed := Rand.r, Rand.fixed
+	if fx.Seed != nil {
+		Rand.r, Rand.fixed = rand.New(rand.NewSource(*fx.Seed)), fx.Seed
+	}
+	Rand.mu.Unlock()
+
+	return func() {
+		Clock.mu.Lock()
+		Clock.now = now
+		Clock.mu.Unlock()
+		Rand.mu.Lock()
+		Rand.r, Rand.fixed = r, fixed
+		Rand.mu.Unlock()
+		time.Local = local
+	}
+}
+
+// Tipsのソースの tips.Clock, tips.Rand, tips.Etc() を普通のGoに戻すための置き換え
+var (
+	clockCall = regexp.MustCompile(`\btips\.Clock\.`)
+	randCall  = regexp.MustCompile(`\btips\.Rand\.`)
+	etcCall   = regexp.MustCompile(`\btips\.Etc\(("(?:[^"\\]|\\.)*")\)`)
+)
+
+// publish はTipsのソースを、ドキュメントとコピペ用の普通のGoに戻します。
+// tips.Clock.Now() は time.Now() に、tips.Rand.Intn() は rand.Intn() に、
+// tips.Etc("/etc/passwd") は "/etc/passwd" になります。行の数は変わりません。
+// どれかを置き換えたら changed は true です。
+func publish(src []byte) (out []byte, changed bool) {
+	out = clockCall.ReplaceAll(src, []byte("time."))
+	out = randCall.ReplaceAll(out, []byte("rand."))
+	out = etcCall.ReplaceAll(out, []byte("$1"))
+	return out, string(out) != string(src)
+}
@@ -0,0 +1,45 @@
+package tips
+
+import "testing"
+
+func TestPublish(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"t := tips.Clock.Now()", "t := time.Now()"},
+		{"d := tips.Clock.Since(t)", "d := time.Since(t)"},
+		{"tips.Rand.Seed(tips.Clock.Now().UnixNano())", "rand.Seed(time.Now().UnixNano())"},
+		{"a[tips.Rand.Intn(len(a))]", "a[rand.Intn(len(a))]"},
+		{`filepath.Walk(tips.Etc("/etc/"), visit)`, `filepath.Walk("/etc/", visit)`},
+		{`isDir(tips.Etc("/etc/\"x\""))`, `isDir("/etc/\"x\"")`},
+		{`tips.Register("pkg/tips_time/tips_time.go")`, `tips.Register("pkg/tips_time/tips_time.go")`},
+	}
+	for _, tt := range tests {
+		got, changed := publish([]byte(tt.in))
+		if string(got) != tt.want || changed != (tt.in != tt.want) {
+			t.Errorf("publish(%q) = %q, %v, want %q", tt.in, got, changed, tt.want)
+		}
+	}
+}
+
+// シードを決めれば、Seed() を呼んでも呼ばなくても同じ系列になる
+func TestFixedSeed(t *testing.T) {
+	seed := int64(1)
+	fx := &Fixed{Seed: &seed}
+
+	restore := fx.set()
+	a := []int{Rand.Intn(100), Rand.Intn(100)}
+	restore()
+
+	restore = fx.set()
+	Rand.Seed(12345)
+	b := []int{Rand.Intn(100), Rand.Intn(100)}
+	restore()
+
+	if a[0] != b[0] || a[1] != b[1] {
+		t.Errorf("got %v and %v, want the same numbers", a, b)
+	}
+	if Rand.fixed != nil {
+		t.Error("set() did not restore tips.Rand")
+	}
+}
@@ -0,0 +1,135 @@
+package tips
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"strings"
+)
+
+// 逆引きRubyの目次。書式はファイルの先頭を参照。
+//
+//go:embed rubytips.txt
+var rubyTOC string
+
+// RubySection は逆引きRubyの節1つ分の見出しです。
+type RubySection struct {
+	Category string // 対応するカテゴリ (例: map)
+	Title    string // 節の名前 (例: ハッシュ)
+	Entries  []RubyEntry
+}
+
+// RubyEntry は逆引きRubyの見出し1つです。
+type RubyEntry struct {
+	Title string // 逆引きRubyの見出し
+	Port  string // 対応するTipsのタイトル。見出しと同じなら空
+	Line  int    // rubytips.txt の行番号
+}
+
+// RubyTOC は逆引きRubyの目次を返します。
+func RubyTOC() []*RubySection {
+	ss, err := parseRubyTOC(rubyTOC)
+	if err != nil {
+		panic(err)
+	}
+	return ss
+}
+
+func parseRubyTOC(src string) ([]*RubySection, error) {
+	var ss []*RubySection
+	sc := bufio.NewScanner(strings.NewReader(src))
+	for n := 1; sc.Scan(); n++ {
+		l := strings.TrimSpace(sc.Text())
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		if strings.HasPrefix(l, "[") {
+			i := strings.Index(l, "]")
+			if i < 0 {
+				return nil, fmt.Errorf("rubytips.txt:%d: missing ]", n)
+			}
+			ss = append(ss, &RubySection{
+				Category: l[1:i],
+				Title:    strings.TrimSpace(l[i+1:]),
+			})
+			continue
+		}
+		if len(ss) == 0 {
+			return nil, fmt.Errorf("rubytips.txt:%d: entry before [category]", n)
+		}
+		e := RubyEntry{Title: l, Line: n}
+		if i := strings.Index(l, " = "); i >= 0 {
+			e.Title, e.Port = strings.TrimSpace(l[:i]), strings.TrimSpace(l[i+3:])
+		}
+		s := ss[len(ss)-1]
+		s.Entries = append(s.Entries, e)
+	}
+	return ss, sc.Err()
+}
+
+// CoverageEntry は逆引きRubyの見出し1つの移植の状況です。
+type CoverageEntry struct {
+	RubyEntry
+	Status string // ported(コードがある), stubbed(見出しと説明だけ), missing(Tipsがない)
+	Tip    *Tip   // ported と stubbed のとき、対応するTips
+}
+
+// Coverage はカテゴリ1つ分の移植の状況です。
+type Coverage struct {
+	Section *RubySection
+	Entries []CoverageEntry
+	Extra   []*Tip // 逆引きRubyの目次にないTips
+}
+
+// Count は状況が status の見出しの数を返します。
+func (c *Coverage) Count(status string) int {
+	n := 0
+	for _, e := range c.Entries {
+		if e.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// RubyCoverage は逆引きRubyの目次と登録されたTipsをタイトルで突き合わせて、
+// 節ごとに移植の状況を返します。
+func RubyCoverage() []*Coverage {
+	var cs []*Coverage
+	for _, s := range RubyTOC() {
+		c := &Coverage{Section: s}
+		var ts []*Tip
+		if cat, ok := LookupCategory(s.Category); ok {
+			ts = cat.Tips
+		}
+		used := map[*Tip]bool{}
+		for _, e := range s.Entries {
+			title := e.Title
+			if e.Port != "" {
+				title = e.Port
+			}
+			ce := CoverageEntry{RubyEntry: e, Status: "missing"}
+			for _, t := range ts {
+				if t.Title != title {
+					continue
+				}
+				ce.Tip = t
+				used[t] = true
+				if t.ID == "" || t.Stub() {
+					ce.Status = "stubbed"
+				} else {
+					ce.Status = "ported"
+				}
+				break
+			}
+			c.Entries = append(c.Entries, ce)
+		}
+		for _, t := range ts {
+			if !used[t] {
+				c.Extra = append(c.Extra, t)
+			}
+		}
+		cs = append(cs, c)
+	}
+	return cs
+}
@@ -0,0 +1,224 @@
+package tips
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Effect はTipsの出力を左右する、プログラムの外の状態です。
+type Effect struct {
+	Kind  string // stdin, clock, random, filesystem, etc, exec, sleep
+	Ident string // 見つかった識別子 (例: os.Stdin) か、"/etc" のような文字列
+}
+
+// 種類ごとの説明
+var effectReasons = map[string]string{
+	"stdin":      "標準入力を読む",
+	"clock":      "現在時刻を使う",
+	"random":     "乱数を使う",
+	"filesystem": "ファイルシステムを使う",
+	"etc":        "/etc を使う",
+	"exec":       "外部コマンドを実行する",
+	"sleep":      "時間待ちで実行順を決めている",
+}
+
+// Reason は Effect の種類の説明を返します。
+func (e Effect) Reason() string {
+	return effectReasons[e.Kind]
+}
+
+// Reasons は Effects() の説明を、同じ種類は 乱数を使う (rand.Intn, rand.Seed) のようにまとめて返します。
+// es は Effects() と同じく種類の順に並んでいるものとします。
+func Reasons(es []Effect) string {
+	var rs []string
+	for i, e := range es {
+		if i > 0 && es[i-1].Kind == e.Kind {
+			rs[len(rs)-1] += ", " + e.Ident
+		} else {
+			rs = append(rs, e.Reason()+" ("+e.Ident)
+		}
+	}
+	if len(rs) == 0 {
+		return ""
+	}
+	return strings.Join(rs, "), ") + ")"
+}
+
+// パッケージのパス.識別子 -> 種類。識別子が * ならそのパッケージの全て。
+// ファイルシステムは fsPackages から求めるので、ここには書きません。
+var effectTable = map[string]string{
+	"os.Stdin":                               "stdin",
+	"os.Args":                                "stdin",
+	"fmt.Scan":                               "stdin",
+	"fmt.Scanln":                             "stdin",
+	"fmt.Scanf":                              "stdin",
+	"github.com/tlorens/go-ibgetkey.ReadKey": "stdin",
+	"time.Now":                               "clock",
+	"time.Since":                             "clock",
+	"math/rand.*":                            "random",
+	"os/exec.Command":                        "exec",
+	"time.Sleep":                             "sleep",
+	"syscall.Stat":                           "filesystem",
+}
+
+// ファイルシステムを使うパッケージ。これらのパッケージレベルの関数と変数は、
+// pureFuncs にあるものを除いてファイルシステムを使うとみなします。
+var fsPackages = map[string]bool{
+	"os":            true,
+	"io/ioutil":     true,
+	"path/filepath": true,
+}
+
+// fsPackages のうち、パス名の文字列だけを扱うものや、標準出力などファイルシステムに関係ないもの
+var pureFuncs = map[string]bool{
+	"os.Stdout":               true,
+	"os.Stderr":               true,
+	"os.Exit":                 true,
+	"os.Getenv":               true,
+	"os.LookupEnv":            true,
+	"os.Environ":              true,
+	"os.Getpid":               true,
+	"os.Getppid":              true,
+	"os.Getuid":               true,
+	"os.Getgid":               true,
+	"os.IsExist":              true,
+	"os.IsNotExist":           true,
+	"os.IsPermission":         true,
+	"os.ErrExist":             true,
+	"os.ErrNotExist":          true,
+	"os.ErrPermission":        true,
+	"io/ioutil.ReadAll":       true,
+	"io/ioutil.NopCloser":     true,
+	"io/ioutil.Discard":       true,
+	"path/filepath.Join":      true,
+	"path/filepath.Base":      true,
+	"path/filepath.Dir":       true,
+	"path/filepath.Ext":       true,
+	"path/filepath.Split":     true,
+	"path/filepath.SplitList": true,
+	"path/filepath.Clean":     true,
+	"path/filepath.IsAbs":     true,
+	"path/filepath.Rel":       true,
+	"path/filepath.Match":     true,
+	"path/filepath.ToSlash":   true,
+	"path/filepath.FromSlash": true,
+	"path/filepath.SkipDir":   true,
+}
+
+// Effects はTipsのコード(補助関数を含む)が使っている外部の状態を返します。
+//
+// Snippet() をgo/typesで型検査して、パッケージレベルの識別子の使われ方から求めます。
+// 標準パッケージ以外はimportせずに検査するので、型の誤りは無視します。
+// ファイルシステムを使うコードの "/etc" で始まる文字列は、サンドボックスの外を指すので "etc" にします。
+func (t *Tip) Effects() []Effect {
+	if t.Stub() {
+		return nil
+	}
+	t.effectsOnce.Do(func() { t.effects = findEffects(t.Snippet()) })
+	return t.effects
+}
+
+func findEffects(src string) []Effect {
+	checkMu.Lock()
+	defer checkMu.Unlock()
+	f, err := parser.ParseFile(checkFset, "main.go", src, 0)
+	if err != nil {
+		return nil
+	}
+	info := &types.Info{Uses: map[*ast.Ident]types.Object{}}
+	conf := types.Config{Importer: stdImporter{}, Error: func(error) {}}
+	conf.Check("main", checkFset, []*ast.File{f}, info)
+
+	found := map[Effect]bool{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.BasicLit:
+			if s, err := strconv.Unquote(n.Value); err == nil && n.Kind == token.STRING && isEtc(s) {
+				found[Effect{"etc", n.Value}] = true
+			}
+		case *ast.SelectorExpr:
+			x, ok := n.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			pkg, ok := info.Uses[x].(*types.PkgName)
+			if !ok {
+				return true
+			}
+			if kind := effectKind(pkg.Imported(), n.Sel.Name); kind != "" {
+				found[Effect{kind, x.Name + "." + n.Sel.Name}] = true
+			}
+		}
+		return true
+	})
+
+	// filepath.Dir("/etc/passwd") のように文字列として扱うだけなら外の /etc は使わない
+	fs := false
+	for e := range found {
+		fs = fs || e.Kind == "filesystem"
+	}
+	es := []Effect{}
+	for e := range found {
+		if e.Kind != "etc" || fs {
+			es = append(es, e)
+		}
+	}
+	sort.Slice(es, func(i, j int) bool {
+		if es[i].Kind != es[j].Kind {
+			return es[i].Kind < es[j].Kind
+		}
+		return strings.Compare(es[i].Ident, es[j].Ident) < 0
+	})
+	return es
+}
+
+// effectKind はパッケージ pkg の識別子 name を使ったときの Effect の種類を返します。
+func effectKind(pkg *types.Package, name string) string {
+	id := pkg.Path() + "." + name
+	if kind, ok := effectTable[id]; ok {
+		return kind
+	}
+	if kind, ok := effectTable[pkg.Path()+".*"]; ok {
+		return kind
+	}
+	if !fsPackages[pkg.Path()] || pureFuncs[id] {
+		return ""
+	}
+	// 型や定数は使ってもファイルシステムに触らない
+	switch pkg.Scope().Lookup(name).(type) {
+	case *types.Func, *types.Var:
+		return "filesystem"
+	}
+	return ""
+}
+
+// /etc か /etc/ で始まるパスか
+func isEtc(s string) bool {
+	return s == "/etc" || strings.HasPrefix(s, "/etc/")
+}
+
+// 型検査は標準パッケージをソースから読むので、読んだものを使い回す
+var (
+	checkMu   sync.Mutex
+	checkFset = token.NewFileSet()
+	srcImp    = importer.ForCompiler(checkFset, "source", nil)
+)
+
+// stdImporter は標準パッケージだけをimportします。
+// それ以外は読みに行かず、go/typesに中身のないパッケージとして扱わせます。
+type stdImporter struct{}
+
+func (stdImporter) Import(path string) (*types.Package, error) {
+	if strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+		return nil, fmt.Errorf("%s: not a standard package", path)
+	}
+	return srcImp.Import(path)
+}
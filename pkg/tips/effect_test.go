@@ -0,0 +1,74 @@
+package tips
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindEffects(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []Effect
+	}{
+		// 一覧に書いていない関数も、os と io/ioutil のパッケージレベルの関数ならファイルシステム
+		{`package main
+
+import (
+	"io/ioutil"
+	"os"
+)
+
+func main() {
+	dir, _ := ioutil.TempDir("", "x")
+	os.MkdirAll(dir+"/a/b", 0777)
+	f, _ := os.OpenFile(dir+"/a/b/c", os.O_CREATE|os.O_WRONLY, 0666)
+	f.Close()
+	os.Symlink("c", dir+"/a/b/d")
+	os.Lstat(dir + "/a/b/d")
+}
+`, []Effect{
+			{"filesystem", "ioutil.TempDir"},
+			{"filesystem", "os.Lstat"},
+			{"filesystem", "os.MkdirAll"},
+			{"filesystem", "os.OpenFile"},
+			{"filesystem", "os.Symlink"},
+		}},
+
+		// パス名の文字列を扱うだけなら何もない。"/etc" も文字列のまま
+		{`package main
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+func main() {
+	fmt.Println(filepath.Join("/etc", "passwd"), filepath.Base("/etc/passwd"))
+}
+`, []Effect{}},
+
+		// 別名でimportしても、パスで判断する
+		{`package main
+
+import (
+	"fmt"
+	r "math/rand"
+	fp "path/filepath"
+)
+
+func main() {
+	fmt.Println(r.Intn(10))
+	fp.Walk("/etc/", nil)
+}
+`, []Effect{
+			{"etc", `"/etc/"`},
+			{"filesystem", "fp.Walk"},
+			{"random", "r.Intn"},
+		}},
+	}
+	for _, tt := range tests {
+		if got := findEffects(tt.src); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("findEffects(%s) = %v, want %v", tt.src, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package tips
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// importSpec はソースファイルのimport 1つです。
+type importSpec struct {
+	Name  string // コード中で使う名前。ドットインポートなら "."
+	Path  string
+	named bool // import set "..." のように名前を明示しているか
+}
+
+// String は import 文に書く形 (例: `set "github.com/deckarep/golang-set"`) を返します。
+func (s *importSpec) String() string {
+	if s.named {
+		return s.Name + " " + strconv.Quote(s.Path)
+	}
+	return strconv.Quote(s.Path)
+}
+
+func fileImports(f *ast.File) []*importSpec {
+	var is []*importSpec
+	for _, spec := range f.Imports {
+		p, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		s := &importSpec{Name: path.Base(p), Path: p}
+		if spec.Name != nil {
+			s.Name = spec.Name.Name
+			s.named = true
+		}
+		if s.Name == "_" {
+			continue
+		}
+		is = append(is, s)
+	}
+	return is
+}
+
+// addImports は is にない名前の import を加えます。
+// tips.Rand を rand に戻すと、ファイルがimportしていない math/rand が必要になるので使います。
+func addImports(is []*importSpec, add ...*importSpec) []*importSpec {
+	for _, a := range add {
+		found := false
+		for _, s := range is {
+			found = found || s.Name == a.Name
+		}
+		if !found {
+			is = append(is, a)
+		}
+	}
+	return is
+}
+
+// InferImports はTipsのコード(補助関数を含む)が使っている識別子から、
+// 必要なimportを import 文に書く形で返します。
+//
+// パッケージ名はTipsのソースファイルのimportから対応をとります。
+// import set "github.com/deckarep/golang-set" のような名前付きのimportはその名前で、
+// それ以外はパスの最後の要素で対応付けるので、パスとパッケージ名が違うパッケージは
+// ソースファイル側で名前を明示してください。
+// ファイル中で宣言されておらず組み込みでもない識別子(heredocのDなど)は、
+// ドットインポートから来たものとみなします。
+func (t *Tip) InferImports() []string {
+	if t.Stub() {
+		return nil
+	}
+	byName := map[string]*importSpec{}
+	var dot []*importSpec
+	for _, s := range t.imports {
+		if s.Name == "." {
+			dot = append(dot, s)
+		} else {
+			byName[s.Name] = s
+		}
+	}
+
+	declared := map[string]bool{}
+	for _, d := range t.decls {
+		for _, n := range d.names {
+			declared[n] = true
+		}
+	}
+
+	need := map[string]bool{}
+	codes := append([]string{t.Code}, t.Helpers()...)
+	for _, code := range codes {
+		for _, name := range unresolved(code) {
+			if s, ok := byName[name]; ok {
+				need[s.String()] = true
+			} else if !declared[name] && types.Universe.Lookup(name) == nil {
+				for _, s := range dot {
+					need[s.String()] = true
+				}
+			}
+		}
+	}
+
+	var is []string
+	for s := range need {
+		is = append(is, s)
+	}
+	// 標準パッケージを先に、それぞれパスの順に並べる
+	sort.Slice(is, func(i, j int) bool {
+		pi, pj := importPath(is[i]), importPath(is[j])
+		if isStd(pi) != isStd(pj) {
+			return isStd(pi)
+		}
+		return pi < pj
+	})
+	return is
+}
+
+// `set "github.com/deckarep/golang-set"` -> github.com/deckarep/golang-set
+func importPath(spec string) string {
+	if i := strings.Index(spec, `"`); i >= 0 {
+		spec = spec[i:]
+	}
+	p, err := strconv.Unquote(spec)
+	if err != nil {
+		return spec
+	}
+	return p
+}
+
+// 標準パッケージかどうか。最初の要素にドットがなければ標準とみなす。
+func isStd(p string) bool {
+	return !strings.Contains(strings.Split(p, "/")[0], ".")
+}
+
+// importBlock は import 文を返します。標準パッケージとそれ以外は空行で分けます。
+func importBlock(is []string) string {
+	switch len(is) {
+	case 0:
+		return ""
+	case 1:
+		return "import " + is[0] + "\n"
+	}
+	var b strings.Builder
+	b.WriteString("import (\n")
+	for i, s := range is {
+		if i > 0 && isStd(importPath(is[i-1])) && !isStd(importPath(s)) {
+			b.WriteString("\n")
+		}
+		b.WriteString("\t" + s + "\n")
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
+
+// ImportWarning はコメントに書いた // import "x" と実際に必要なimportの食い違いです。
+type ImportWarning struct {
+	File string
+	Line int
+	Msg  string
+}
+
+func (w ImportWarning) String() string {
+	return fmt.Sprintf("%s:%d: %s", w.File, w.Line, w.Msg)
+}
+
+var spaces = regexp.MustCompile(`\s+`)
+
+// ImportWarnings はコメントに書いた import と、コードから求めたimportを比べます。
+// 使われていないものと、書き漏れているものを返します。
+// コメントが1つもなければ、書き漏れは警告しません。
+func (t *Tip) ImportWarnings() []ImportWarning {
+	if t.Stub() {
+		return nil
+	}
+	inferred := t.InferImports()
+	need := map[string]bool{}
+	for _, s := range inferred {
+		need[s] = true
+	}
+
+	var ws []ImportWarning
+	hinted := map[string]bool{}
+	for i, l := range strings.Split(t.Code, "\n") {
+		m := importHint.FindStringSubmatch(l)
+		if m == nil {
+			continue
+		}
+		h := spaces.ReplaceAllString(m[1], " ")
+		hinted[h] = true
+		if !need[h] {
+			msg := fmt.Sprintf("%s: import %s is not used", t.ID, h)
+			for _, s := range inferred {
+				if path.Base(importPath(s)) == path.Base(importPath(h)) && s != h {
+					msg += fmt.Sprintf("; did you mean %s?", s)
+				}
+			}
+			ws = append(ws, ImportWarning{t.File, t.CodeLine(i), msg})
+		}
+	}
+	if len(hinted) == 0 {
+		// コメントがなければ推定に任せる
+		return ws
+	}
+	for _, s := range inferred {
+		// fmtは常に追加していたので書かれていない
+		if !hinted[s] && s != `"fmt"` {
+			ws = append(ws, ImportWarning{t.File, t.Line, fmt.Sprintf("%s: missing // import %s", t.ID, s)})
+		}
+	}
+	return ws
+}
+
+// unresolved はコード中で宣言されずに使われている名前を返します。
+func unresolved(code string) []string {
+	f, err := parser.ParseFile(token.NewFileSet(), "", "package p\n"+code, 0)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, id := range f.Unresolved {
+		names = append(names, id.Name)
+	}
+	return names
+}
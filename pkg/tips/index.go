@@ -0,0 +1,139 @@
+package tips
+
+import (
+	"go/scanner"
+	"go/token"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SearchDoc は検索結果に表示するTips 1つ分です。
+type SearchDoc struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Category string `json:"category"` // カテゴリのタイトル (例: 文字列)
+	URL      string `json:"url"`      // 例: tips_string.html#string_Succ
+}
+
+// SearchIndex はサイトの検索ボックスが読む転置インデックスです。
+// Tokens はトークンから、それを含む Docs の添字への対応です。
+type SearchIndex struct {
+	Docs   []SearchDoc      `json:"docs"`
+	Tokens map[string][]int `json:"tokens"`
+}
+
+// BuildSearchIndex は登録された全てのTipsのID・タイトル・説明・Rubyのメソッド名・コード中の識別子から
+// 検索インデックスを作ります。新しい書き方の版のコードも、もとのTipsに含めます。
+// 見出しだけのTipsはIDがないので含みません。
+func BuildSearchIndex() *SearchIndex {
+	idx := &SearchIndex{Tokens: map[string][]int{}}
+	for _, c := range Categories() {
+		for _, t := range c.Tips {
+			if t.ID == "" {
+				continue
+			}
+			n := len(idx.Docs)
+			idx.Docs = append(idx.Docs, SearchDoc{
+				ID:       t.ID,
+				Title:    t.Title,
+				Category: c.Title,
+				URL:      "tips_" + c.Name + ".html#" + t.ID,
+			})
+
+			seen := map[string]bool{}
+			text := []string{t.ID, t.Title, t.Description}
+			text = append(text, t.Ruby...)
+			for _, v := range WithVariants([]*Tip{t}) {
+				text = append(text, identifiers(v.Code)...)
+			}
+			for _, s := range text {
+				for _, tok := range Tokenize(s) {
+					if !seen[tok] {
+						seen[tok] = true
+						idx.Tokens[tok] = append(idx.Tokens[tok], n)
+					}
+				}
+			}
+		}
+	}
+	return idx
+}
+
+// Tokenize は文字列を検索用のトークンに分けます。
+//
+// 英数字と _ の並びは小文字にした単語を1つ、_ を含めば _ で区切った各部分もトークンにします
+// (slice_Flatten -> slice_flatten, slice, flatten)。
+// 日本語などそれ以外の文字の並びは、隣り合う2文字ずつ(bigram)をトークンにします。
+// 1文字だけの並びはその1文字です。
+// 検索ボックスのJavaScript(doc/html/search.js)も同じ規則で分けています。
+func Tokenize(s string) []string {
+	var toks []string
+	s = strings.ToLower(s)
+	for len(s) > 0 {
+		r, n := utf8.DecodeRuneInString(s)
+		switch {
+		case isWordRune(r):
+			i := 0
+			for i < len(s) && isWordRune(rune(s[i])) {
+				i++
+			}
+			w := s[:i]
+			toks = append(toks, w)
+			if strings.Contains(w, "_") {
+				for _, p := range strings.Split(w, "_") {
+					if p != "" {
+						toks = append(toks, p)
+					}
+				}
+			}
+			s = s[i:]
+		case r >= utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsNumber(r)):
+			var rs []rune
+			for len(s) > 0 {
+				r, n := utf8.DecodeRuneInString(s)
+				if r < utf8.RuneSelf || !(unicode.IsLetter(r) || unicode.IsNumber(r)) {
+					break
+				}
+				rs = append(rs, r)
+				s = s[n:]
+			}
+			if len(rs) == 1 {
+				toks = append(toks, string(rs))
+			}
+			for i := 0; i+1 < len(rs); i++ {
+				toks = append(toks, string(rs[i:i+2]))
+			}
+		default:
+			s = s[n:]
+		}
+	}
+	return toks
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || '0' <= r && r <= '9' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z'
+}
+
+// identifiers はコード中の識別子を返します。文字列やコメントは含みません。
+func identifiers(code string) []string {
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	s.Init(fset.AddFile("", fset.Base(), len(code)), []byte(code), nil, 0)
+
+	seen := map[string]bool{}
+	var ids []string
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.IDENT && !seen[lit] {
+			seen[lit] = true
+			ids = append(ids, lit)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
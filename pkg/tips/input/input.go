@@ -0,0 +1,156 @@
+/*
+対話的なTipsのキー入力と行入力です。
+
+Tipsは keyboard.ReadKey() や fmt.Scanln() で os.Stdin から読みます。Feed() でスクリプトを
+流し込むと、os.Stdin をパイプに差し替えてスクリプトどおりに書き込むので、
+goroutine_Kill のようにキー入力を待つTipsも端末なしで最後まで実行できます。
+
+	restore := input.Feed(input.MustParse("wait 300ms\nkey .\n"))
+	defer restore()
+*/
+
+package input
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Event はスクリプトの1行で、Wait だけ待ってから Text を入力します。
+type Event struct {
+	Wait time.Duration
+	Text string
+}
+
+// Script は端末への入力を時間の流れとともに書いたものです。
+type Script []Event
+
+// Feed は os.Stdin をパイプに差し替え、スクリプトを入力として流し込みます。
+// wait は Feed() を呼んでからの時間の流れで待ち、最後まで書くとパイプを閉じるので、
+// その後の読み込みはEOFになります。
+// 戻り値の関数を呼ぶと、書き込みをやめて os.Stdin を元に戻します。
+func Feed(s Script) (restore func()) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		panic("input: " + err.Error())
+	}
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer w.Close()
+		for _, e := range s {
+			select {
+			case <-stop:
+				return
+			case <-time.After(e.Wait):
+			}
+			if _, err := io.WriteString(w, e.Text); err != nil {
+				return
+			}
+		}
+	}()
+
+	stdin := os.Stdin
+	os.Stdin = r
+	return func() {
+		os.Stdin = stdin
+		close(stop)
+		r.Close() // 読まれずに残った分の書き込みを終わらせる
+		<-done
+	}
+}
+
+// Parse はスクリプトを読みます。1行に1つ、次のように書きます。
+//
+//	# コメント
+//	wait 300ms   300ms待つ
+//	key .        "."を1文字入力する
+//	line 4       "4"と改行を入力する
+//
+// key と line の引数は "\n" のようにクオートしてもかまいません。
+func Parse(r io.Reader) (Script, error) {
+	var s Script
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		l := strings.TrimSpace(sc.Text())
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		cmd, arg := l, ""
+		if i := strings.IndexAny(l, " \t"); i >= 0 {
+			cmd, arg = l[:i], strings.TrimSpace(l[i+1:])
+		}
+		if strings.HasPrefix(arg, `"`) {
+			u, err := strconv.Unquote(arg)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", n, err)
+			}
+			arg = u
+		}
+
+		switch cmd {
+		case "wait":
+			d, err := time.ParseDuration(arg)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", n, err)
+			}
+			s = append(s, Event{Wait: d})
+		case "key":
+			if utf8.RuneCountInString(arg) != 1 {
+				return nil, fmt.Errorf("line %d: key needs exactly one character", n)
+			}
+			s = append(s, Event{Text: arg})
+		case "line":
+			s = append(s, Event{Text: arg + "\n"})
+		default:
+			return nil, fmt.Errorf("line %d: unknown command %q", n, cmd)
+		}
+	}
+	return s, sc.Err()
+}
+
+// MustParse は文字列のスクリプトを読みます。書式が誤っていればpanicします。
+func MustParse(s string) Script {
+	sc, err := Parse(strings.NewReader(s))
+	if err != nil {
+		panic("input: " + err.Error())
+	}
+	return sc
+}
+
+// String は Parse() で読める書式でスクリプトを返します。
+func (s Script) String() string {
+	var b strings.Builder
+	for _, e := range s {
+		switch {
+		case e.Wait > 0:
+			fmt.Fprintf(&b, "wait %v\n", e.Wait)
+		case e.Text == "":
+		case utf8.RuneCountInString(e.Text) == 1 && e.Text != "\n":
+			fmt.Fprintf(&b, "key %s\n", quote(e.Text))
+		case strings.HasSuffix(e.Text, "\n"):
+			fmt.Fprintf(&b, "line %s\n", quote(strings.TrimSuffix(e.Text, "\n")))
+		default:
+			// 改行で終わらない複数文字は1文字ずつ
+			for _, r := range e.Text {
+				fmt.Fprintf(&b, "key %s\n", quote(string(r)))
+			}
+		}
+	}
+	return b.String()
+}
+
+// 前後の空白や " で始まる場合などは読み違えないようにクオートする
+func quote(s string) string {
+	if s == "" || strings.TrimSpace(s) != s || strings.HasPrefix(s, `"`) || strconv.Quote(s) != `"`+s+`"` {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,100 @@
+package input
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want Script
+	}{
+		{"empty", "", nil},
+		{"comments and blank lines", "# comment\n\n   \n\t# indented comment\n", nil},
+		{"wait", "wait 300ms\n", Script{{Wait: 300 * time.Millisecond}}},
+		{"key", "key .\n", Script{{Text: "."}}},
+		{"key multibyte", "key あ\n", Script{{Text: "あ"}}},
+		{"key quoted", `key "\n"`, Script{{Text: "\n"}}},
+		{"key quoted space", `key " "`, Script{{Text: " "}}},
+		{"line", "line 4\n", Script{{Text: "4\n"}}},
+		{"line empty", "line\n", Script{{Text: "\n"}}},
+		{"line quoted", `line " -1 "`, Script{{Text: " -1 \n"}}},
+		{"tab separated", "wait\t1s\nkey\tq\n", Script{{Wait: time.Second}, {Text: "q"}}},
+		{"no trailing newline", "line 9", Script{{Text: "9\n"}}},
+		{"script", "wait 350ms\nkey .\nwait 500ms\nkey .\n", Script{
+			{Wait: 350 * time.Millisecond}, {Text: "."},
+			{Wait: 500 * time.Millisecond}, {Text: "."},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %#v, want %#v", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{"unknown command", "press .\n", `line 1: unknown command "press"`},
+		{"command without space", "key.\n", `line 1: unknown command "key."`},
+		{"key without character", "key\n", "line 1: key needs exactly one character"},
+		{"key with two characters", "key ab\n", "line 1: key needs exactly one character"},
+		{"key quoted empty", `key ""`, "line 1: key needs exactly one character"},
+		{"wait without duration", "wait\n", "line 1: time: invalid duration"},
+		{"wait without unit", "wait 300\n", "line 1: time: missing unit in duration"},
+		{"wait bad unit", "wait 3 days\n", "line 1: time: unknown unit"},
+		{"wait bad number", "wait fast\n", "line 1: time: invalid duration"},
+		{"unterminated quote", `line "abc`, "line 1: invalid syntax"},
+		{"line number counts comments", "# comment\n\nkey .\nwait x\n", "line 4: time: invalid duration"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(strings.NewReader(tt.src))
+			if err == nil {
+				t.Fatalf("Parse(%q) = %v, want error", tt.src, s)
+			}
+			if !strings.HasPrefix(err.Error(), tt.err) {
+				t.Errorf("Parse(%q) error = %q, want prefix %q", tt.src, err, tt.err)
+			}
+		})
+	}
+}
+
+// String() で書いたスクリプトは Parse() で同じものに読めること
+func TestStringRoundTrip(t *testing.T) {
+	s := Script{
+		{Wait: 350 * time.Millisecond}, {Text: "."},
+		{Text: " "}, {Text: "\n"}, {Text: `"`},
+		{Text: "4\n"}, {Text: " -1 \n"},
+	}
+	got, err := Parse(strings.NewReader(s.String()))
+	if err != nil {
+		t.Fatalf("%v\n%s", err, s)
+	}
+	if !reflect.DeepEqual(got, s) {
+		t.Errorf("Parse(String()) = %#v, want %#v\n%s", got, s, s)
+	}
+}
+
+func TestMustParsePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustParse did not panic on a bad script")
+		}
+	}()
+	MustParse("wait soon\n")
+}
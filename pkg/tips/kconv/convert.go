@@ -0,0 +1,174 @@
+package kconv
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// Auto のときに判定に使う先頭のバイト数
+const guessSize = 64 * 1024
+
+// NewReader は from の文字コードで書かれた r を、UTF-8で読む io.Reader を返します。
+// BOMは取り除きます。
+//
+// from が Auto なら、先頭の64KiBを Guess() で判定します。
+// その範囲がASCIIだけのときは、そのまま読みます。
+func NewReader(r io.Reader, from Encoding) io.Reader {
+	if from == Auto {
+		br := bufio.NewReaderSize(r, guessSize)
+		b, _ := br.Peek(guessSize) // 短ければエラーになるが、読めた分で判定する
+		from, r = Guess(b), br
+	}
+	if from == UTF8BOM {
+		r = transform.NewReader(r, &trimBOM{})
+	}
+	if e := textEncoding(from); e != nil {
+		r = transform.NewReader(r, e.NewDecoder())
+	}
+	return r
+}
+
+// NewWriter は書き込まれたUTF-8を to の文字コードにして w に書く io.WriteCloser を返します。
+// UTF8BOM なら最初にBOMを書きます。
+//
+// 変換しきれていない分を書き出し、ISO-2022-JPではASCIIに戻すエスケープシーケンスを
+// 書くので、最後に必ず Close() を呼んでください。w は閉じません。
+func NewWriter(w io.Writer, to Encoding) io.WriteCloser {
+	var t transform.Transformer = transform.Nop
+	if e := textEncoding(to); e != nil {
+		t = e.NewEncoder()
+	}
+	if to == UTF8BOM {
+		t = transform.Chain(&addBOM{}, t)
+	}
+	return transform.NewWriter(w, t)
+}
+
+// 変換に使うx/textの文字コード。UTF-8とASCIIは変換しないのでnil
+func textEncoding(e Encoding) encoding.Encoding {
+	switch e {
+	case ShiftJIS:
+		return japanese.ShiftJIS
+	case EUCJP:
+		return japanese.EUCJP
+	case ISO2022JP:
+		return japanese.ISO2022JP
+	}
+	return nil
+}
+
+// 先頭のBOMを取り除く
+type trimBOM struct{ done bool }
+
+func (t *trimBOM) Reset() { t.done = false }
+
+func (t *trimBOM) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	if !t.done {
+		if len(src) < len(bom) && bytes.HasPrefix(bom, src) && !atEOF {
+			return 0, 0, transform.ErrShortSrc
+		}
+		if bytes.HasPrefix(src, bom) {
+			nSrc = len(bom)
+		}
+		t.done = true
+	}
+	n := copy(dst, src[nSrc:])
+	if n < len(src)-nSrc {
+		err = transform.ErrShortDst
+	}
+	return n, nSrc + n, err
+}
+
+// 先頭にBOMを足す
+type addBOM struct{ done bool }
+
+func (t *addBOM) Reset() { t.done = false }
+
+func (t *addBOM) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	if !t.done {
+		if len(dst) < len(bom) {
+			return 0, 0, transform.ErrShortDst
+		}
+		nDst = copy(dst, bom)
+		t.done = true
+	}
+	n := copy(dst[nDst:], src)
+	if n < len(src) {
+		err = transform.ErrShortDst
+	}
+	return nDst + n, n, err
+}
+
+// Newline は改行(LF、CRLF、CR)をすべて eol にそろえる transform.Transformer を返します。
+// UTF-8かASCIIの上で使います。
+//
+//	r := transform.NewReader(f, kconv.Newline("\r\n"))
+func Newline(eol string) transform.Transformer {
+	return &newline{eol: []byte(eol)}
+}
+
+type newline struct {
+	transform.NopResetter
+	eol []byte
+}
+
+func (t *newline) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		c := src[nSrc]
+		if c != '\r' && c != '\n' {
+			if nDst == len(dst) {
+				return nDst, nSrc, transform.ErrShortDst
+			}
+			dst[nDst] = c
+			nDst++
+			nSrc++
+			continue
+		}
+
+		n := 1
+		if c == '\r' {
+			if nSrc+1 == len(src) && !atEOF {
+				return nDst, nSrc, transform.ErrShortSrc // 次がLFかもしれない
+			}
+			if nSrc+1 < len(src) && src[nSrc+1] == '\n' {
+				n = 2
+			}
+		}
+		if nDst+len(t.eol) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], t.eol)
+		nSrc += n
+	}
+	return nDst, nSrc, nil
+}
+
+// NewlineOf は b の改行の種類を "LF", "CRLF", "CR" で返します。
+// 改行がなければ ""、混ざっていれば "MIXED" です。
+func NewlineOf(b []byte) string {
+	kind := ""
+	for i := 0; i < len(b); i++ {
+		k := ""
+		switch {
+		case b[i] == '\r' && i+1 < len(b) && b[i+1] == '\n':
+			k = "CRLF"
+			i++
+		case b[i] == '\r':
+			k = "CR"
+		case b[i] == '\n':
+			k = "LF"
+		default:
+			continue
+		}
+		if kind != "" && kind != k {
+			return "MIXED"
+		}
+		kind = k
+	}
+	return kind
+}
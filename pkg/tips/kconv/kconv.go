@@ -0,0 +1,218 @@
+/*
+日本語の文字コードの判定と変換です。RubyのKconvやnkfの代わりに使います。
+
+Shift_JIS, EUC-JP, ISO-2022-JP, UTF-8 (BOMのあるものとないもの) を
+バイト列から判定し、io.Reader や io.Writer をはさんで少しずつ変換します。
+
+	r := kconv.NewReader(f, kconv.Auto)       // 判定してUTF-8で読む
+	w := kconv.NewWriter(os.Stdout, kconv.ShiftJIS) // UTF-8をShift_JISで書く
+	defer w.Close()
+	io.Copy(w, r)
+
+全角・半角、ひらがな・カタカナを変換する transform.Transformer (ToHankaku など) もあります。
+*/
+
+package kconv
+
+import (
+	"bytes"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Encoding は文字コードです。
+type Encoding int
+
+const (
+	Auto      Encoding = iota // 読むときに判定する
+	Binary                    // どの文字コードとしても読めない
+	ASCII                     // 7ビットの文字だけ
+	UTF8                      // BOMのないUTF-8
+	UTF8BOM                   // BOMのあるUTF-8
+	ShiftJIS                  // Shift_JIS (CP932)
+	EUCJP                     // EUC-JP
+	ISO2022JP                 // ISO-2022-JP (JIS)
+)
+
+var names = map[Encoding]string{
+	Auto:      "AUTO",
+	Binary:    "BINARY",
+	ASCII:     "ASCII",
+	UTF8:      "UTF-8",
+	UTF8BOM:   "UTF-8 (BOM)",
+	ShiftJIS:  "Shift_JIS",
+	EUCJP:     "EUC-JP",
+	ISO2022JP: "ISO-2022-JP",
+}
+
+// String はnkf -gと同じ名前を返します。
+func (e Encoding) String() string {
+	return names[e]
+}
+
+var bom = []byte("\xef\xbb\xbf")
+
+// Guess は b の文字コードを判定します。
+//
+// BOMやISO-2022-JPのエスケープシーケンスがあればそれで決め、なければ
+// Scores() の点がいちばん高いものを選びます。同点ならUTF-8、Shift_JIS、EUC-JPの順です。
+// 半角カナだけのShift_JISとEUC-JPの漢字のように、どちらとも読めるものは前のほうになります。
+// 末尾で切れている文字は、続きがあるものとして数えます。
+func Guess(b []byte) Encoding {
+	if bytes.HasPrefix(b, bom) {
+		return UTF8BOM
+	}
+	if isJIS(b) {
+		return ISO2022JP
+	}
+	if isASCII(b) {
+		return ASCII
+	}
+
+	best, max := Binary, -1
+	for _, s := range Scores(b) {
+		if s.Score > max {
+			best, max = s.Encoding, s.Score
+		}
+	}
+	return best
+}
+
+// Score は文字コードごとの点です。
+type Score struct {
+	Encoding Encoding
+	Score    int // その文字コードとして読めなければ -1
+}
+
+// Scores はUTF-8、Shift_JIS、EUC-JPのそれぞれとして b を読んだときの点を返します。
+//
+// 読み出した1文字ごとに、文字の種類の重みにその文字のバイト数を掛けて足します。
+// 同じバイト列を短い文字に細かく分けて読んだほうが得をしないように、バイト数を掛けます。
+// 重みは、かな・全角の記号とEUC-JPの半角カナ(SS2の付いたもの)が3、漢字・全角英数字・
+// Shift_JISの半角カナ・UTF-8のアクセント付きの文字などが2、それ以外が1、ASCIIが0です。
+// UTF-8で3バイトになる日本語の文字は、他の文字コードのバイト列がたまたまそう読めることは
+// まずないので4にします。
+// 文字コードとして正しくないバイト列があれば -1 です。
+func Scores(b []byte) []Score {
+	return []Score{
+		{UTF8, scoreUTF8(b)},
+		{ShiftJIS, scoreShiftJIS(b)},
+		{EUCJP, scoreEUCJP(b)},
+	}
+}
+
+func isASCII(b []byte) bool {
+	for _, c := range b {
+		if c >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// 8ビットの文字がなく、漢字やASCIIへ切り替えるエスケープシーケンスがある
+func isJIS(b []byte) bool {
+	if !isASCII(b) {
+		return false
+	}
+	for _, esc := range []string{"\x1b$B", "\x1b$@", "\x1b(J", "\x1b(I"} {
+		if bytes.Contains(b, []byte(esc)) {
+			return true
+		}
+	}
+	return false
+}
+
+func scoreUTF8(b []byte) int {
+	score := 0
+	for len(b) > 0 {
+		r, n := utf8.DecodeRune(b)
+		switch {
+		case r == utf8.RuneError && n <= 1:
+			if !utf8.FullRune(b) {
+				return score // 末尾で切れている
+			}
+			return -1
+		case n == 1:
+		case 0x3000 <= r && r <= 0x30ff || 0x4e00 <= r && r <= 0x9fff || 0xff01 <= r && r <= 0xff9f: // かな・漢字・全角の文字・半角カナ
+			score += 4 * n
+		case unicode.IsLetter(r):
+			score += 2 * n
+		default:
+			score += n
+		}
+		b = b[n:]
+	}
+	return score
+}
+
+func scoreEUCJP(b []byte) int {
+	score := 0
+	for i := 0; i < len(b); {
+		c := b[i]
+		n, lo, hi := 2, byte(0xa1), byte(0xfe)
+		switch {
+		case c < 0x80:
+			i++
+			continue
+		case c == 0x8e: // SS2: 次の1バイトが半角カナ
+			lo, hi = 0xa1, 0xdf
+		case c == 0x8f: // SS3: 次の2バイトが補助漢字
+			n = 3
+		case 0xa1 <= c && c <= 0xfe:
+		default:
+			return -1
+		}
+		if i+n > len(b) {
+			return score
+		}
+		for _, t := range b[i+1 : i+n] {
+			if t < lo || hi < t {
+				return -1
+			}
+		}
+		switch {
+		case c == 0x8e || c == 0xa1 || c == 0xa4 || c == 0xa5: // 半角カナ・記号・ひらがな・カタカナ
+			score += 3 * n
+		case c == 0xa3 || 0xb0 <= c && c <= 0xf4: // 英数字・漢字
+			score += 2 * n
+		default:
+			score += n
+		}
+		i += n
+	}
+	return score
+}
+
+func scoreShiftJIS(b []byte) int {
+	score := 0
+	for i := 0; i < len(b); {
+		c := b[i]
+		switch {
+		case c < 0x80:
+			i++
+		case 0xa1 <= c && c <= 0xdf: // 半角カナ
+			score += 2
+			i++
+		case 0x81 <= c && c <= 0x9f || 0xe0 <= c && c <= 0xfc:
+			if i+1 == len(b) {
+				return score
+			}
+			if t := b[i+1]; t < 0x40 || t == 0x7f || 0xfc < t {
+				return -1
+			}
+			switch {
+			case c == 0x81 || c == 0x83 || c == 0x82 && b[i+1] >= 0x9f: // 記号・ひらがな・カタカナ
+				score += 6
+			case c == 0x82 || 0x88 <= c && c <= 0x9f || 0xe0 <= c && c <= 0xea: // 英数字・漢字
+				score += 4
+			default:
+				score += 2
+			}
+			i += 2
+		default:
+			return -1
+		}
+	}
+	return score
+}
@@ -0,0 +1,83 @@
+package kconv
+
+import (
+	"testing"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+func encode(t *testing.T, e Encoding, s string) []byte {
+	t.Helper()
+	if e == UTF8 {
+		return []byte(s)
+	}
+	b, err := textEncoding(e).NewEncoder().Bytes([]byte(s))
+	if err != nil {
+		t.Fatalf("%v: %q: %v", e, s, err)
+	}
+	return b
+}
+
+func TestGuess(t *testing.T) {
+	texts := []string{
+		"ｱｲｳ",
+		"ﾃｽﾄです",
+		"漢字",
+		"漢字です",
+		"こんにちは、世界",
+		"逆引きRuby",
+		"ＧＯ言語　１．４",
+		"2015年5月5日 (火) 07:23:30",
+		"カタカナとひらがな",
+		"ﾊﾝｶｸｶﾅ",
+	}
+	for _, s := range texts {
+		for _, e := range []Encoding{UTF8, EUCJP, ShiftJIS} {
+			b := encode(t, e, s)
+			if got := Guess(b); got != e {
+				t.Errorf("Guess(%v %q [% x]) = %v, want %v (scores %v)", e, s, b, got, e, Scores(b))
+			}
+		}
+	}
+}
+
+func TestGuessBytes(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Encoding
+	}{
+		{"", ASCII},
+		{"hello\n", ASCII},
+		{"Café", UTF8},
+		{"naïve résumé", UTF8},
+		{"Ελληνικά", UTF8},
+		{"\xef\xbb\xbfabc", UTF8BOM},
+		{"\x1b$B4A;z\x1b(B", ISO2022JP},
+		{"\xb1\xb2\xb3", ShiftJIS},          // ｱｲｳ
+		{"\x8e\xb1\x8e\xb2\x8e\xb3", EUCJP}, // ｱｲｳ
+		{"\x8e\xb1\x8e\xb2", EUCJP},         // ｱｲ
+		{"\xe3\x81\x82\xe3", UTF8},          // あ と切れた文字
+		{"\xff\xfe\x00\x00", Binary},
+		{"\x80\x80\x80", Binary},
+	}
+	for _, tt := range tests {
+		if got := Guess([]byte(tt.in)); got != tt.want {
+			t.Errorf("Guess(% x) = %v, want %v (scores %v)", tt.in, got, tt.want, Scores([]byte(tt.in)))
+		}
+	}
+}
+
+func TestScoresSS2(t *testing.T) {
+	// SS2(0x8e)の次は半角カナ(0xa1-0xdf)だけ
+	for _, in := range []string{"\x8e\xe0", "\x8e\xfe", "\x8e\x41"} {
+		for _, s := range Scores([]byte(in)) {
+			if s.Encoding == EUCJP && s.Score != -1 {
+				t.Errorf("EUC-JP score of % x = %d, want -1", in, s.Score)
+			}
+		}
+	}
+	b, _ := japanese.EUCJP.NewEncoder().Bytes([]byte("ｱ"))
+	if string(b) != "\x8e\xb1" {
+		t.Fatalf("EUC-JP ｱ = % x", b)
+	}
+}
@@ -0,0 +1,147 @@
+package kconv
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+)
+
+// 全角・半角、ひらがな・カタカナの変換です。nkfの-Z1や-h1などの代わりに使います。
+// どれも transform.Transformer なので、NewReader() の後ろに transform.NewReader() でつなげたり、
+// transform.Chain() で組み合わせたりできます。
+
+// ToHankaku は全角の英数字・記号(！から～まで)と全角の空白を半角にします。
+var ToHankaku transform.Transformer = runes.Map(func(r rune) rune {
+	switch {
+	case r == '　':
+		return ' '
+	case '！' <= r && r <= '～':
+		return r - '！' + '!'
+	}
+	return r
+})
+
+// ToZenkaku は半角の英数字・記号と空白を全角にします。
+var ToZenkaku transform.Transformer = runes.Map(func(r rune) rune {
+	switch {
+	case r == ' ':
+		return '　'
+	case '!' <= r && r <= '~':
+		return r - '!' + '！'
+	}
+	return r
+})
+
+var (
+	// ToZenkakuKana は半角カナを全角にします。後ろの濁点・半濁点はまとめて1文字にします(ｶﾞ→ガ)。
+	ToZenkakuKana transform.Transformer = zenkakuKana{}
+	// ToHankakuKana は全角カナを半角にします。濁点・半濁点は分け、句読点やかぎかっこは全角のままです。
+	ToHankakuKana transform.Transformer = hankakuKana{}
+)
+
+// 半角カナ(U+FF61からU+FF9F)に対応する全角の文字
+var kanaTable = []rune("。「」、・ヲァィゥェォャュョッーアイウエオカキクケコサシスセソタチツテトナニヌネノハヒフヘホマミムメモヤユヨラリルレロワン゛゜")
+
+// 濁点・半濁点の付いた文字と、付ける前の文字
+var (
+	dakuten    = []rune("ガカギキグクゲケゴコザサジシズスゼセゾソダタヂチヅツデテドトバハビヒブフベヘボホヴウヷワヺヲ")
+	handakuten = []rune("パハピヒプフペヘポホ")
+)
+
+// 半角カナと濁点・半濁点 -> 全角
+var composed = func() map[[2]rune]rune {
+	m := map[[2]rune]rune{}
+	for i := 0; i < len(dakuten); i += 2 {
+		m[[2]rune{dakuten[i+1], 'ﾞ'}] = dakuten[i]
+	}
+	for i := 0; i < len(handakuten); i += 2 {
+		m[[2]rune{handakuten[i+1], 'ﾟ'}] = handakuten[i]
+	}
+	return m
+}()
+
+// 全角カナ -> 半角カナ(濁点・半濁点を含む)
+var decomposed = func() map[rune]string {
+	m := map[rune]string{}
+	for i, r := range kanaTable {
+		if 'ァ' <= r && r <= 'ヺ' || r == 'ー' || r == '゛' || r == '゜' {
+			m[r] = string(rune(0xff61 + i))
+		}
+	}
+	for i := 0; i < len(dakuten); i += 2 {
+		m[dakuten[i]] = m[dakuten[i+1]] + "ﾞ"
+	}
+	for i := 0; i < len(handakuten); i += 2 {
+		m[handakuten[i]] = m[handakuten[i+1]] + "ﾟ"
+	}
+	return m
+}()
+
+type zenkakuKana struct{ transform.NopResetter }
+
+func (zenkakuKana) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		if !atEOF && !utf8.FullRune(src[nSrc:]) {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+		r, n := utf8.DecodeRune(src[nSrc:])
+		if 0xff61 <= r && r <= 0xff9f {
+			z := kanaTable[r-0xff61]
+			// 後ろの濁点・半濁点を合成する
+			rest := src[nSrc+n:]
+			if !atEOF && !utf8.FullRune(rest) {
+				return nDst, nSrc, transform.ErrShortSrc
+			}
+			if m, mn := utf8.DecodeRune(rest); mn > 0 {
+				if c, ok := composed[[2]rune{z, m}]; ok {
+					z, n = c, n+mn
+				}
+			}
+			r = z
+		}
+		if nDst+utf8.RuneLen(r) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += utf8.EncodeRune(dst[nDst:], r)
+		nSrc += n
+	}
+	return nDst, nSrc, nil
+}
+
+type hankakuKana struct{ transform.NopResetter }
+
+func (hankakuKana) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		if !atEOF && !utf8.FullRune(src[nSrc:]) {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+		r, n := utf8.DecodeRune(src[nSrc:])
+		out := src[nSrc : nSrc+n]
+		if h, ok := decomposed[r]; ok {
+			out = []byte(h)
+		}
+		if nDst+len(out) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], out)
+		nSrc += n
+	}
+	return nDst, nSrc, nil
+}
+
+// ToKatakana はひらがな(ぁからゖ、ゝゞ)をカタカナにします。
+var ToKatakana transform.Transformer = runes.Map(func(r rune) rune {
+	if 'ぁ' <= r && r <= 'ゖ' || r == 'ゝ' || r == 'ゞ' {
+		return r + 0x60
+	}
+	return r
+})
+
+// ToHiragana はカタカナをひらがなにします。ヷやヺのようにひらがなのないカタカナと、半角カナはそのままです。
+var ToHiragana transform.Transformer = runes.Map(func(r rune) rune {
+	if 'ァ' <= r && r <= 'ヶ' || r == 'ヽ' || r == 'ヾ' {
+		return r - 0x60
+	}
+	return r
+})
@@ -0,0 +1,61 @@
+package kconv
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/text/transform"
+)
+
+func TestZenkaku(t *testing.T) {
+	tests := []struct {
+		name string
+		t    transform.Transformer
+		in   string
+		want string
+	}{
+		{"ToHankaku", ToHankaku, "ＡＢＣ　１２３！＠＃　カナ", "ABC 123!@# カナ"},
+		{"ToHankaku", ToHankaku, "～｛｝", "~{}"},
+		{"ToZenkaku", ToZenkaku, "Go 1.4 (2014)", "Ｇｏ　１．４　（２０１４）"},
+		{"ToZenkaku", ToZenkaku, "ｶﾅ\n", "ｶﾅ\n"},
+		{"ToZenkakuKana", ToZenkakuKana, "ｶﾞｷﾞｸﾞ ﾊﾟﾋﾟﾌﾟ ｳﾞｧｲｵﾘﾝ ｺﾝﾆﾁﾊ｡", "ガギグ パピプ ヴァイオリン コンニチハ。"},
+		{"ToZenkakuKana", ToZenkakuKana, "ｱﾞ ﾞ ｶ", "ア゛ ゛ カ"},
+		{"ToZenkakuKana", ToZenkakuKana, "｢ﾜﾞｦﾞ｣", "「ヷヺ」"},
+		{"ToHankakuKana", ToHankakuKana, "ガギグ パピプ ヴァイオリン コンニチハ。", "ｶﾞｷﾞｸﾞ ﾊﾟﾋﾟﾌﾟ ｳﾞｧｲｵﾘﾝ ｺﾝﾆﾁﾊ。"},
+		{"ToHankakuKana", ToHankakuKana, "ヷヺー", "ﾜﾞｦﾞｰ"},
+		{"ToHankakuKana", ToHankakuKana, "ひらがな", "ひらがな"},
+		{"ToKatakana", ToKatakana, "ひらがなをかたかなに。ゔぁいおりん", "ヒラガナヲカタカナニ。ヴァイオリン"},
+		{"ToHiragana", ToHiragana, "カタカナヲヒラガナニ。ヾ", "かたかなをひらがなに。ゞ"},
+		{"ToHiragana", ToHiragana, "ヷｶﾅ", "ヷｶﾅ"},
+	}
+	for _, tt := range tests {
+		got, _, err := transform.String(tt.t, tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("%s(%q) = %q, %v, want %q", tt.name, tt.in, got, err, tt.want)
+		}
+	}
+}
+
+// 濁点や1文字が書き込みの境目で分かれていても合成すること
+func TestZenkakuKanaSplit(t *testing.T) {
+	in := "ﾃﾞｰﾀﾍﾞｰｽ ﾊﾟﾝ"
+	for size := 1; size <= len(in); size++ {
+		var b strings.Builder
+		w := transform.NewWriter(&b, ToZenkakuKana)
+		for i := 0; i < len(in); i += size {
+			end := i + size
+			if end > len(in) {
+				end = len(in)
+			}
+			if _, err := w.Write([]byte(in[i:end])); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := b.String(), "データベース パン"; got != want {
+			t.Errorf("writing %d bytes at a time: got %q, want %q", size, got, want)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package tips
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Diagnostic は Lint() が見つけた問題です。
+type Diagnostic struct {
+	File    string
+	Line    int
+	Msg     string
+	Warning bool // ドキュメントは作れるが直したほうがよいもの
+}
+
+func (d Diagnostic) String() string {
+	if d.Warning {
+		return fmt.Sprintf("%s:%d: warning: %s", d.File, d.Line, d.Msg)
+	}
+	return fmt.Sprintf("%s:%d: %s", d.File, d.Line, d.Msg)
+}
+
+// 正しい形の区切り行
+var canonicalSeparator = regexp.MustCompile(`^//-{3,}$`)
+
+// コメントアウトされた関数
+var commentedFunc = regexp.MustCompile(`^//\s*func\s+(\w+)\s*\(`)
+
+// Lint は pkg/tips_HOGE/tips_HOGE.go がドキュメント生成の決まりどおりに書かれているか確かめ、
+// 見つけた問題をファイルと行の順に返します。
+//
+// Register() と違ってソースだけを見るので、init() の登録が食い違っていてpanicするファイルも調べられます。
+// 確かめるのは次のことです。
+//
+//   - 区切りが //--- の形で、開き・名前・閉じの3行が揃っている
+//   - ブロックごとに カテゴリ_Name の関数が1つだけある
+//   - その関数が init() の tips.Register() に同じ名前で登録されている
+//   - SetRuby() などに書いたIDのTipsがある
+//   - 同じ名前の補助関数を、コメントアウトして残したり別のカテゴリでも宣言したりしていない(警告)
+//   - 説明のコメント /* */ がある(警告)
+func Lint(filenames []string) ([]Diagnostic, error) {
+	var ds []Diagnostic
+	helpers := map[string]Diagnostic{} // 補助関数の名前 -> 最初に宣言した場所
+	for _, filename := range filenames {
+		src, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		l, err := lintFile(filename, src)
+		if err != nil {
+			return nil, err
+		}
+		ds = append(ds, l.diags...)
+
+		for _, h := range l.helpers {
+			if first, ok := helpers[h.Msg]; ok && first.File != h.File {
+				ds = append(ds, Diagnostic{h.File, h.Line,
+					fmt.Sprintf("helper %s is also declared at %s:%d", h.Msg, first.File, first.Line), true})
+			} else if !ok {
+				helpers[h.Msg] = h
+			}
+		}
+	}
+	sort.SliceStable(ds, func(i, j int) bool {
+		if ds[i].File != ds[j].File {
+			return ds[i].File < ds[j].File
+		}
+		return ds[i].Line < ds[j].Line
+	})
+	return ds, nil
+}
+
+// linter は1つのファイルを調べた結果です。
+type linter struct {
+	filename string
+	fset     *token.FileSet
+	diags    []Diagnostic
+	helpers  []Diagnostic // 補助関数の名前(Msg)と場所
+}
+
+func (l *linter) errorf(pos token.Pos, format string, args ...interface{}) {
+	l.diags = append(l.diags, Diagnostic{l.filename, l.fset.Position(pos).Line, fmt.Sprintf(format, args...), false})
+}
+
+func (l *linter) warnf(pos token.Pos, format string, args ...interface{}) {
+	l.diags = append(l.diags, Diagnostic{l.filename, l.fset.Position(pos).Line, fmt.Sprintf(format, args...), true})
+}
+
+func (l *linter) line(pos token.Pos) int {
+	return l.fset.Position(pos).Line
+}
+
+// lintBlock は区切りで囲まれた名前と、その後ろのコードの範囲です。
+type lintBlock struct {
+	open       *ast.Comment
+	title      string
+	start, end token.Pos
+}
+
+func lintFile(filename string, src []byte) (*linter, error) {
+	l := &linter{filename: filename, fset: token.NewFileSet()}
+	f, err := parser.ParseFile(l.fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	category := CategoryOf(filename)
+
+	// 区切りと名前
+	var seps []*ast.Comment
+	titles := map[int]string{} // 行 -> コメント
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			if separator.MatchString(c.Text) {
+				seps = append(seps, c)
+				if !canonicalSeparator.MatchString(c.Text) {
+					l.errorf(c.Pos(), "separator %q should be //---", c.Text)
+				}
+			} else if strings.HasPrefix(c.Text, "//") {
+				titles[l.line(c.Pos())] = strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+			}
+			if m := commentedFunc.FindStringSubmatch(c.Text); m != nil {
+				if o := f.Scope.Lookup(m[1]); o != nil {
+					l.warnf(c.Pos(), "commented-out copy of %s (declared at line %d)", m[1], l.line(o.Pos()))
+				}
+			}
+		}
+	}
+	var blocks []*lintBlock
+	for i := 0; i < len(seps); {
+		open := seps[i]
+		if i+1 == len(seps) {
+			l.errorf(open.Pos(), "separator has no closing separator")
+			break
+		}
+		close := seps[i+1]
+		if l.line(close.Pos()) != l.line(open.Pos())+2 {
+			l.errorf(open.Pos(), "separator is not followed by a title line and a closing separator")
+			i++
+			continue
+		}
+		title := titles[l.line(open.Pos())+1]
+		if title == "" {
+			l.errorf(open.Pos(), "block has no title")
+		}
+		b := &lintBlock{open: open, title: title, start: close.End(), end: f.End()}
+		if len(blocks) > 0 {
+			blocks[len(blocks)-1].end = open.Pos()
+		}
+		blocks = append(blocks, b)
+		i += 2
+	}
+
+	// ブロックごとの関数と説明
+	ids := map[string]token.Pos{}
+	for _, b := range blocks {
+		var tipFuncs []*ast.FuncDecl
+		hasDecl, isRegistration := false, false
+		for _, d := range f.Decls {
+			if d.Pos() < b.start || d.Pos() >= b.end {
+				continue
+			}
+			if gd, ok := d.(*ast.GenDecl); ok && gd.Tok == token.IMPORT {
+				continue
+			}
+			hasDecl = true
+			fd, ok := d.(*ast.FuncDecl)
+			if !ok {
+				if gd := d.(*ast.GenDecl); gd.Tok == token.TYPE {
+					for _, s := range gd.Specs {
+						l.helpers = append(l.helpers, Diagnostic{filename, l.line(s.Pos()), s.(*ast.TypeSpec).Name.Name, true})
+					}
+				}
+				continue
+			}
+			switch {
+			case fd.Recv != nil:
+			case fd.Name.Name == "init":
+				isRegistration = true
+			case strings.HasPrefix(fd.Name.Name, category+"_"):
+				tipFuncs = append(tipFuncs, fd)
+			default:
+				l.helpers = append(l.helpers, Diagnostic{filename, l.line(fd.Pos()), fd.Name.Name, true})
+			}
+		}
+		if isRegistration {
+			continue
+		}
+
+		switch {
+		case len(tipFuncs) == 0 && hasDecl:
+			l.errorf(b.open.Pos(), "block %q has code but no %s_ function", b.title, category)
+		case len(tipFuncs) > 1:
+			var names []string
+			for _, fd := range tipFuncs {
+				names = append(names, fd.Name.Name)
+			}
+			l.errorf(b.open.Pos(), "block %q has %d %s_ functions (%s); put each in its own block",
+				b.title, len(tipFuncs), category, strings.Join(names, ", "))
+		}
+		for _, fd := range tipFuncs {
+			name := strings.TrimPrefix(fd.Name.Name, category+"_")
+			if r, _ := utf8.DecodeRuneInString(name); !unicode.IsUpper(r) {
+				l.errorf(fd.Pos(), "%s should be named %s_Name with an upper-case Name", fd.Name.Name, category)
+			}
+			ids[fd.Name.Name] = fd.Pos()
+		}
+
+		hasDesc := false
+		for _, cg := range f.Comments {
+			for _, c := range cg.List {
+				if c.Pos() >= b.start && c.Pos() < b.end && strings.HasPrefix(c.Text, "/*") {
+					hasDesc = true
+				}
+			}
+		}
+		if !hasDesc {
+			if len(tipFuncs) > 0 {
+				l.warnf(b.open.Pos(), "%s has no description", tipFuncs[0].Name.Name)
+			} else {
+				l.warnf(b.open.Pos(), "block %q has neither code nor description", b.title)
+			}
+		}
+	}
+
+	l.lintRegistration(f, ids)
+	return l, nil
+}
+
+// lintRegistration は init() で登録したIDとブロックの関数を突き合わせます。
+func (l *linter) lintRegistration(f *ast.File, ids map[string]token.Pos) {
+	registered := map[string]bool{}
+	found := false
+	for _, d := range f.Decls {
+		fd, ok := d.(*ast.FuncDecl)
+		if !ok || fd.Name.Name != "init" || fd.Recv != nil {
+			continue
+		}
+		ast.Inspect(fd.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if x, ok := sel.X.(*ast.Ident); !ok || x.Name != "tips" {
+				return true
+			}
+
+			var m *ast.CompositeLit
+			switch sel.Sel.Name {
+			case "Register":
+				found = true
+				if len(call.Args) == 4 {
+					m, _ = call.Args[3].(*ast.CompositeLit)
+				}
+			case "SetRuby", "SetInputs", "SetBenchmarks":
+				if len(call.Args) == 1 {
+					m, _ = call.Args[0].(*ast.CompositeLit)
+				}
+			default:
+				return true
+			}
+			if m == nil {
+				return true
+			}
+			for _, e := range m.Elts {
+				kv, ok := e.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				lit, ok := kv.Key.(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				id, _ := strconv.Unquote(lit.Value)
+				if _, ok := ids[id]; !ok {
+					l.errorf(kv.Pos(), "tips.%s: %s has no block", sel.Sel.Name, id)
+					continue
+				}
+				if sel.Sel.Name != "Register" {
+					continue
+				}
+				registered[id] = true
+				if v, ok := kv.Value.(*ast.Ident); !ok || v.Name != id {
+					l.errorf(kv.Pos(), "tips.Register: %s is registered as a different function", id)
+				}
+			}
+			return true
+		})
+	}
+	if !found {
+		l.errorf(f.Package, "no tips.Register call in init()")
+		return
+	}
+	for id, pos := range ids {
+		if !registered[id] {
+			l.errorf(pos, "%s is not registered in tips.Register", id)
+		}
+	}
+}
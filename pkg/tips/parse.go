@@ -0,0 +1,273 @@
+/*
+Tipsの抽出
+
+pkg/tips_HOGE/tips_HOGE.go を go/parser で読み、//--- で区切られた
+ブロックをTipsとして取り出します。
+*/
+
+package tips
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/ashitani/golangtips/pkg/tips/input"
+)
+
+// Tip は //--- で区切られた1つのTipsです。
+type Tip struct {
+	ID          string       // 関数名 (例: string_Concat)。コードのない見出しだけのTipsでは空
+	Category    string       // ファイル名から tips_ を除いたもの (例: string)
+	Title       string       // 区切りの間に書かれた名前
+	Description string       // /* */ の中身(markdown)
+	Code        string       // 説明コメントを除いたブロックのソース
+	Imports     []string     // コメントアウトして書いてある import (例: `"strings"`)
+	File        string       // ソースのファイル名
+	Line        int          // 見出しの行番号
+	Func        func()       // Register()で登録された関数
+	Input       input.Script // SetInputs()で登録された、端末なしで実行するときの入力
+	Ruby        []string     // SetRuby()で登録された、逆引きRubyで対応するメソッド (例: Array#assoc)
+	Go          string       // 新しい書き方の版で、必要なGoのバージョン (例: go1.21)。もとのTipsでは空
+	Variants    []*Tip       // 新しい書き方の版。Goのバージョンの古い順
+
+	codeLine int // Codeの1行目のファイル上の行番号
+	descAt   int // 説明コメントを取り除いた位置(Codeの行)
+	descN    int // 取り除いた行数
+
+	bench func() // SetBenchmarks()で登録された補助関数のベンチマーク
+
+	effectsOnce sync.Once
+	effects     []Effect // Effects()の結果
+
+	decls      []*decl       // ファイル全体のトップレベルの宣言
+	imports    []*importSpec // ファイルのimport
+	start, end int           // ブロックのファイル上の範囲
+}
+
+// decl はトップレベルの宣言1つです。
+type decl struct {
+	names  []string // 宣言している名前
+	recv   string   // メソッドならレシーバの型名
+	text   string   // ドキュメントコメントを含むソース
+	offset int
+}
+
+var importHint = regexp.MustCompile(`(?m)^\s*//\s*import\s+(.+?)\s*$`)
+
+// 区切り行。make_doc.rb と同じく //- の後に何か続けば区切りとみなします。
+var separator = regexp.MustCompile(`//-.`)
+
+// CategoryOf はファイル名からカテゴリ名を求めます。
+// "pkg/tips_string/tips_string.go" -> "string"
+func CategoryOf(filename string) string {
+	base := strings.TrimSuffix(filepath.Base(filename), ".go")
+	return strings.TrimPrefix(base, "tips_")
+}
+
+// ParseFile は filename を読んでTipsを抽出します。
+func ParseFile(filename string) ([]*Tip, error) {
+	return Parse(filename, nil)
+}
+
+// Parse は src (nilならfilenameの中身)からTipsを抽出します。
+//
+// ブロックは
+//
+//	//---(開き)
+//	// 名前
+//	//---(閉じ)
+//	/* 説明 */
+//	func カテゴリ_名前() { ... }
+//
+// の形で、次の開きの区切りまでがそのTipsのコードです。
+// tips.Clock.Now() のような実行用のフックは、publish() で普通のGoに戻してから読みます。
+// カテゴリ_ で始まる関数がなく、他の関数だけを含むブロック(Tips_HOGE()など)は
+// Tipsとして扱いません。
+func Parse(filename string, src []byte) ([]*Tip, error) {
+	if src == nil {
+		var err error
+		src, err = ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// tips.Clock などはドキュメントに出さない
+	src, published := publish(src)
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	file := fset.File(f.Pos())
+	category := CategoryOf(filename)
+
+	var comments []*ast.Comment
+	var seps []*ast.Comment
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			comments = append(comments, c)
+			if separator.MatchString(c.Text) {
+				seps = append(seps, c)
+			}
+		}
+	}
+
+	decls := topLevelDecls(f, file, src)
+	imports := fileImports(f)
+	if published {
+		imports = addImports(imports, &importSpec{Name: "time", Path: "time"}, &importSpec{Name: "rand", Path: "math/rand"})
+	}
+
+	var tips []*Tip
+	for i := 0; i+1 < len(seps); i += 2 {
+		open, close := seps[i], seps[i+1]
+
+		// 本体は閉じの次の行から次の開きの行の手前まで
+		start := lineOffset(file, file.Line(close.Pos())+1, len(src))
+		end := len(src)
+		if i+2 < len(seps) {
+			end = lineOffset(file, file.Line(seps[i+2].Pos()), len(src))
+		}
+
+		t := &Tip{
+			Category: category,
+			File:     filename,
+			Line:     file.Line(open.Pos()),
+			codeLine: file.Line(close.Pos()) + 1,
+			decls:    decls,
+			imports:  imports,
+			start:    start,
+			end:      end,
+		}
+		for _, c := range comments {
+			if c.Pos() > open.End() && c.End() < close.Pos() {
+				t.Title = strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+			}
+		}
+
+		hasFunc := false
+		for _, d := range f.Decls {
+			fd, ok := d.(*ast.FuncDecl)
+			if !ok || file.Offset(fd.Pos()) < start || file.Offset(fd.Pos()) >= end {
+				continue
+			}
+			hasFunc = true
+			if fd.Recv == nil && strings.HasPrefix(fd.Name.Name, category+"_") && t.ID == "" {
+				t.ID = fd.Name.Name
+			}
+		}
+		if hasFunc && t.ID == "" {
+			continue
+		}
+
+		code := string(src[start:end])
+		for _, c := range comments {
+			o := file.Offset(c.Pos())
+			if o < start || o >= end || !strings.HasPrefix(c.Text, "/*") {
+				continue
+			}
+			t.Description = strings.TrimSuffix(strings.TrimPrefix(c.Text, "/*"), "*/")
+
+			// コメントとその後ろの空白・改行1つを取り除く
+			from, to := o-start, file.Offset(c.End())-start
+			for to < len(code) && code[to] == ' ' {
+				to++
+			}
+			if to < len(code) && code[to] == '\n' {
+				to++
+			}
+			t.descAt = strings.Count(code[:from], "\n")
+			t.descN = strings.Count(code[from:to], "\n")
+			code = code[:from] + code[to:]
+			break
+		}
+		t.Code = code
+		for _, m := range importHint.FindAllStringSubmatch(code, -1) {
+			t.Imports = append(t.Imports, m[1])
+		}
+		tips = append(tips, t)
+	}
+	return tips, nil
+}
+
+func topLevelDecls(f *ast.File, file *token.File, src []byte) []*decl {
+	var ds []*decl
+	for _, d := range f.Decls {
+		pos := d.Pos()
+		dl := &decl{}
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			if d.Doc != nil {
+				pos = d.Doc.Pos()
+			}
+			dl.names = []string{d.Name.Name}
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				dl.names = nil
+				dl.recv = receiverType(d.Recv.List[0].Type)
+			}
+		case *ast.GenDecl:
+			if d.Doc != nil {
+				pos = d.Doc.Pos()
+			}
+			for _, s := range d.Specs {
+				switch s := s.(type) {
+				case *ast.TypeSpec:
+					dl.names = append(dl.names, s.Name.Name)
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						dl.names = append(dl.names, n.Name)
+					}
+				}
+			}
+		}
+		dl.offset = file.Offset(pos)
+		dl.text = string(src[dl.offset:file.Offset(d.End())])
+		ds = append(ds, dl)
+	}
+	return ds
+}
+
+// *T や T[K] のようなレシーバの型から型名を取り出す
+func receiverType(x ast.Expr) string {
+	for {
+		switch t := x.(type) {
+		case *ast.StarExpr:
+			x = t.X
+		case *ast.IndexExpr:
+			x = t.X
+		case *ast.IndexListExpr:
+			x = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// Stub はコードがなく見出しと説明だけのTipsかどうかを返します。
+func (t *Tip) Stub() bool {
+	return strings.Replace(t.Code, "\n", "", -1) == ""
+}
+
+// CodeLine はCodeのi行目(0始まり)のファイル上の行番号を返します。
+func (t *Tip) CodeLine(i int) int {
+	if i >= t.descAt {
+		i += t.descN
+	}
+	return t.codeLine + i
+}
+
+// line行目の先頭のオフセット。ファイル末尾を超えたらsize。
+func lineOffset(file *token.File, line, size int) int {
+	if line > file.LineCount() {
+		return size
+	}
+	return file.Offset(file.LineStart(line))
+}
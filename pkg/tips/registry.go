@@ -0,0 +1,269 @@
+package tips
+
+import (
+	"fmt"
+	"go/version"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/ashitani/golangtips/pkg/tips/input"
+)
+
+// Category は tips_HOGE パッケージ1つ分のTipsです。
+type Category struct {
+	Name  string // string, time, ...
+	Title string // 文字列, 日付と時刻, ...
+	Tips  []*Tip
+}
+
+// サイトの目次の順番
+var order = []string{
+	"string",
+	"time",
+	"num",
+	"slice",
+	"map",
+	"regexp",
+	"file",
+	"dir",
+	"goroutine",
+}
+
+var (
+	categories = map[string]*Category{}
+	byID       = map[string]*Tip{}
+)
+
+// Register はカテゴリのTipsを登録します。各tips_HOGEパッケージのinit()から呼ばれます。
+//
+// src はそのパッケージのソース(filename)で、ID・タイトル・説明・importはここから取り出します。
+// funcs はIDから関数への対応で、ソースのTipsと過不足があればpanicします。
+// IDが HOGE_hoge_go1_21 のTipsは、HOGE_hoge をGo 1.21以降の書き方にした版として
+// HOGE_hoge の Variants に入り、カテゴリの一覧には出ません。
+func Register(filename, title string, src []byte, funcs map[string]func()) {
+	ts, err := Parse(filename, src)
+	if err != nil {
+		panic(err)
+	}
+	c := &Category{Name: CategoryOf(filename), Title: title, Tips: ts}
+	if _, dup := categories[c.Name]; dup {
+		panic("tips: category " + c.Name + " registered twice")
+	}
+
+	for _, t := range ts {
+		if t.ID == "" {
+			continue
+		}
+		f, ok := funcs[t.ID]
+		if !ok {
+			panic(fmt.Sprintf("tips: %s is not registered", t.ID))
+		}
+		t.Func = f
+		byID[t.ID] = t
+	}
+	for id := range funcs {
+		if _, ok := byID[id]; !ok {
+			panic(fmt.Sprintf("tips: %s is not found in %s", id, filename))
+		}
+	}
+	c.Tips = attachVariants(c.Tips)
+	categories[c.Name] = c
+}
+
+// 新しい書き方の版の関数名。slice_Reverse_go1_21 は slice_Reverse のGo 1.21以降の版
+var variantName = regexp.MustCompile(`^(.+)_go(1_[0-9]+)$`)
+
+// attachVariants は新しい書き方の版をもとのTipsの Variants に移し、残りを返します。
+func attachVariants(ts []*Tip) []*Tip {
+	var rest []*Tip
+	for _, t := range ts {
+		m := variantName.FindStringSubmatch(t.ID)
+		if m == nil {
+			rest = append(rest, t)
+			continue
+		}
+		base, ok := byID[m[1]]
+		if !ok {
+			panic(fmt.Sprintf("tips: %s is a variant of unknown tip %s", t.ID, m[1]))
+		}
+		t.Go = "go" + strings.Replace(m[2], "_", ".", 1)
+		base.Variants = append(base.Variants, t)
+		sort.SliceStable(base.Variants, func(i, j int) bool {
+			return version.Compare(base.Variants[i].Go, base.Variants[j].Go) < 0
+		})
+	}
+	return rest
+}
+
+// SetInputs はキー入力や行入力を待つTipsに、端末なしで実行するときの入力を登録します。
+// scripts はIDから input.Parse() の書式のスクリプトへの対応で、Register() の後に呼びます。
+func SetInputs(scripts map[string]string) {
+	for id, s := range scripts {
+		t, ok := byID[id]
+		if !ok {
+			panic(fmt.Sprintf("tips: %s is not registered", id))
+		}
+		t.Input = input.MustParse(s)
+	}
+}
+
+// Categories は登録されたカテゴリを目次の順に返します。
+func Categories() []*Category {
+	rank := func(name string) int {
+		for i, n := range order {
+			if n == name {
+				return i
+			}
+		}
+		return len(order)
+	}
+	cs := []*Category{}
+	for _, c := range categories {
+		cs = append(cs, c)
+	}
+	sort.Slice(cs, func(i, j int) bool {
+		ri, rj := rank(cs[i].Name), rank(cs[j].Name)
+		if ri != rj {
+			return ri < rj
+		}
+		return cs[i].Name < cs[j].Name
+	})
+	return cs
+}
+
+// LookupCategory はカテゴリを名前で探します。"tips_string"のように書いてもかまいません。
+func LookupCategory(name string) (*Category, bool) {
+	c, ok := categories[strings.TrimPrefix(name, "tips_")]
+	return c, ok
+}
+
+// All は登録された全てのTipsを目次の順に返します。見出しだけのTipsも含みます。
+func All() []*Tip {
+	ts := []*Tip{}
+	for _, c := range Categories() {
+		ts = append(ts, c.Tips...)
+	}
+	return ts
+}
+
+// Lookup はTipsをIDで探します。新しい書き方の版もIDで探せます。
+func Lookup(id string) (*Tip, bool) {
+	t, ok := byID[id]
+	return t, ok
+}
+
+// WithVariants は ts のそれぞれの直後に、新しい書き方の版を加えて返します。
+func WithVariants(ts []*Tip) []*Tip {
+	var all []*Tip
+	for _, t := range ts {
+		all = append(all, t)
+		all = append(all, t.Variants...)
+	}
+	return all
+}
+
+// Variant はGoのバージョン goVersion (例: go1.21.3) で使える一番新しい版を返します。
+// 新しい書き方の版が使えなければTips自身です。goVersion が読めない形でもTips自身を返します。
+func (t *Tip) Variant(goVersion string) *Tip {
+	v := t
+	if !version.IsValid(goVersion) {
+		return v
+	}
+	for _, m := range t.Variants {
+		if version.Compare(goVersion, m.Go) >= 0 {
+			v = m
+		}
+	}
+	return v
+}
+
+// ForToolchain は実行中のGoで使える一番新しい版を返します。
+// develop 版のGoのようにバージョンが読めなければ、一番新しい版です。
+func (t *Tip) ForToolchain() *Tip {
+	if v := runtime.Version(); version.IsValid(v) || len(t.Variants) == 0 {
+		return t.Variant(v)
+	}
+	return t.Variants[len(t.Variants)-1]
+}
+
+// Run はTipsを実行します。見出しだけのTipsでは何もしません。
+// ファイルシステムを使うTipsは、使い捨てのサンドボックスの中で実行します。
+//
+// 環境変数 GOLANGTIPS_NOW か GOLANGTIPS_SEED があれば、実行中は tips.Clock と tips.Rand を
+// その時刻とシードにします。/etc を使うTipsは tips.Etc() でサンドボックスの etc を読み、
+// 出力に出てきたサンドボックスの etc は /etc に戻します。
+func (t *Tip) Run() error {
+	if t.Func == nil {
+		return nil
+	}
+	fx, err := FixedFromEnv()
+	if err != nil {
+		return err
+	}
+	defer fx.set()()
+
+	if !t.Sandboxed() {
+		asProgram(t.Func)
+		return nil
+	}
+	s, err := NewSandbox()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	if !t.usesEtc() {
+		return s.Run(t.Func)
+	}
+
+	etcDir = filepath.Join(s.Dir, "etc")
+	defer func() { etcDir = "" }()
+	var out string
+	var runErr error
+	if err := s.Run(func() { out, runErr = Capture(t.Func) }); err != nil {
+		return err
+	}
+	os.Stdout.WriteString(strings.Replace(out, etcDir, "/etc", -1))
+	return runErr
+}
+
+// RunUnattended は端末から読まずにTipsを実行します。
+// 入力には SetInputs() で登録したスクリプトを使い、なければ入力は空です。
+func (t *Tip) RunUnattended() error {
+	restore := input.Feed(t.Input)
+	defer restore()
+	return t.Run()
+}
+
+// Sandboxed はTipsがサンドボックスの中で実行されるならtrueを返します。
+func (t *Tip) Sandboxed() bool {
+	for _, e := range t.Effects() {
+		if e.Kind == "filesystem" {
+			return true
+		}
+	}
+	return false
+}
+
+// /etc を使うTipsか。Effects() は /etc をファイルシステムと一緒にしか返さない
+func (t *Tip) usesEtc() bool {
+	for _, e := range t.Effects() {
+		if e.Kind == "etc" {
+			return true
+		}
+	}
+	return false
+}
+
+// Run はカテゴリのTipsを順に実行します。新しい書き方の版があれば、実行中のGoで使えるものを選びます。
+func (c *Category) Run() error {
+	for _, t := range c.Tips {
+		if err := t.ForToolchain().Run(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
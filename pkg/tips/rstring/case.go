@@ -0,0 +1,28 @@
+package rstring
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// Swapcase はRubyのString#swapcaseと同じく、1文字ずつ大文字(とǅのようなタイトルケース)を小文字に、
+// 小文字を大文字にします。ßのように大文字にすると2文字になるものは golang.org/x/text/cases で変換します。
+func Swapcase(s string) string {
+	// cases.Caser は状態を持ちgoroutineの間で共有できないので、呼ぶたびに1組作って全部の文字に使う
+	upper, lower := cases.Upper(language.Und), cases.Lower(language.Und)
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case unicode.IsUpper(r) || unicode.IsTitle(r):
+			b.WriteString(lower.String(string(r)))
+		case unicode.IsLower(r):
+			b.WriteString(upper.String(string(r)))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
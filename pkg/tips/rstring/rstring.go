@@ -0,0 +1,240 @@
+/*
+RubyのStringのメソッドのうち、Goの標準パッケージにないものです。
+
+	rstring.Succ("az")                   // => "ba"
+	rstring.Upto("a8", "b2", false, f) // a8 a9 b0 b1 b2 を順に f に渡す
+
+Rubyと同じく、英数字として扱うのはASCIIの a-z, A-Z, 0-9 だけです。
+それ以外の文字はrune単位で、文字コードの順に進めたり戻したりします。
+*/
+
+package rstring
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Succ はRubyのString#succと同じ規則で"次"の文字列を返します。
+//
+//   - 一番右の英数字を1つ進めます。z、Z、9のように桁あふれしたら、左の英数字に繰り上げます。
+//   - 英数字の間の記号は飛ばして繰り上げます。ただし、数字と英字が記号で区切られている所では
+//     繰り上げずに、そこに文字を足します("No.9"が"No.10"になる)。
+//   - 左端まで繰り上がったら、a、A、1のうち桁あふれした文字に合わせたものを足します。
+//   - 英数字がなければ、一番右の文字を文字コードで1つ進めます。空文字列の次は空文字列です。
+func Succ(s string) string {
+	rs := []rune(s)
+	if len(rs) == 0 {
+		return ""
+	}
+
+	carry, carryPos := rune(0), -1
+	var last rune // 最後に桁あふれした英数字
+	skipped := false
+	for i := len(rs) - 1; i >= 0; i-- {
+		if skipped && last != 0 && crossesKind(last, rs[i]) {
+			break
+		}
+		next, c, wrapped, ok := succAlnum(rs[i])
+		if !ok {
+			skipped = true
+			continue
+		}
+		skipped = false
+		rs[i] = next
+		if !wrapped {
+			return string(rs)
+		}
+		last = next
+		carry, carryPos = c, i
+	}
+
+	// 英数字がなければ文字コードで進める
+	if carryPos < 0 {
+		for i := len(rs) - 1; i >= 0; i-- {
+			if rs[i] < unicode.MaxRune {
+				rs[i] = nextRune(rs[i])
+				return string(rs)
+			}
+			rs[i] = 0
+		}
+		carry, carryPos = 1, 0
+	}
+	rs = append(rs[:carryPos], append([]rune{carry}, rs[carryPos:]...)...)
+	return string(rs)
+}
+
+// Pred はSucc()の逆です。Rubyにはありません。
+// Succ(t)がsになる文字列tのうち一番短いものを返し、そのようなtがなければ空文字列を返します。
+//
+// 繰り下がりの途中で、Succ()が左端に足した文字(a、A、1)に当たったら、
+// 取り除いたものも候補にしてSucc()で確かめます。
+func Pred(s string) string {
+	rs := []rune(s)
+	borrowed := false
+	found := false // 英数字があった
+	var last rune  // 最後に桁借りした英数字
+	skipped := false
+	for i := len(rs) - 1; i >= 0; i-- {
+		if skipped && last != 0 && crossesKind(last, rs[i]) {
+			break
+		}
+		prev, wrapped, ok := predAlnum(rs[i])
+		if !ok {
+			skipped = true
+			continue
+		}
+		skipped, found = false, true
+
+		// Succ()が足した文字なら取り除く
+		if borrowed {
+			t := string(append(append([]rune{}, rs[:i]...), rs[i+1:]...))
+			if Succ(t) == s {
+				return t
+			}
+		}
+		rs[i] = prev
+		if !wrapped {
+			return checkPred(string(rs), s)
+		}
+		borrowed, last = true, prev
+	}
+	if found {
+		return ""
+	}
+
+	// 英数字がなければ文字コードで戻す
+	for i := len(rs) - 1; i >= 0; i-- {
+		if rs[i] > 0 {
+			rs[i] = prevRune(rs[i])
+			return checkPred(string(rs), s)
+		}
+	}
+	return ""
+}
+
+// Upto はRubyのString#uptoと同じく、fromからSucc()で進めながらtoまでの文字列をfに渡します。
+// exclusive が true なら to は渡しません。
+//
+//   - 1文字どうしなら文字コードの順に進めます("9"から"A"までなら記号も含む)。
+//   - 両方が数字だけなら数として数え、fromの桁数になるよう0で埋めます。
+//   - それ以外は、fromがtoより大きければ何もしません。toまで進むか、toより長くなったら止めます。
+func Upto(from, to string, exclusive bool, f func(string)) {
+	// 1文字どうし
+	if utf8.RuneCountInString(from) == 1 && utf8.RuneCountInString(to) == 1 {
+		c, e := []rune(from)[0], []rune(to)[0]
+		for ; c < e || c == e && !exclusive; c = nextRune(c) {
+			f(string(c))
+		}
+		return
+	}
+
+	// 数字だけ
+	if isDigits(from) && isDigits(to) {
+		b, err1 := strconv.ParseInt(from, 10, 64)
+		e, err2 := strconv.ParseInt(to, 10, 64)
+		if err1 == nil && err2 == nil {
+			for ; b < e || b == e && !exclusive; b++ {
+				f(fmt.Sprintf("%0*d", len(from), b))
+			}
+			return
+		}
+	}
+
+	if from > to || exclusive && from == to {
+		return
+	}
+	end := Succ(to)
+	for s := from; s != end; {
+		f(s)
+		if s == to {
+			break
+		}
+		s = Succ(s)
+		if exclusive && s == to || len(s) > len(to) || s == "" {
+			break
+		}
+	}
+}
+
+// succAlnum は英数字 c の次の文字を返します。英数字でなければ ok は false です。
+// z、Z、9なら a、A、0 に戻して wrapped を true にし、繰り上がりで左に足す文字を carry に返します。
+func succAlnum(c rune) (next, carry rune, wrapped, ok bool) {
+	switch {
+	case c == '9':
+		return '0', '1', true, true
+	case c == 'z':
+		return 'a', 'a', true, true
+	case c == 'Z':
+		return 'A', 'A', true, true
+	case isAlnum(c):
+		return c + 1, 0, false, true
+	}
+	return c, 0, false, false
+}
+
+// predAlnum は英数字 c の前の文字を返します。英数字でなければ ok は false です。
+// a、A、0なら z、Z、9 にして wrapped を true にします。
+func predAlnum(c rune) (prev rune, wrapped, ok bool) {
+	switch {
+	case c == '0':
+		return '9', true, true
+	case c == 'a':
+		return 'z', true, true
+	case c == 'A':
+		return 'Z', true, true
+	case isAlnum(c):
+		return c - 1, false, true
+	}
+	return c, false, false
+}
+
+// t の次が s ならtを、そうでなければ空文字列を返す
+func checkPred(t, s string) string {
+	if Succ(t) != s {
+		return ""
+	}
+	return t
+}
+
+func isAlpha(r rune) bool { return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' }
+func isDigit(r rune) bool { return '0' <= r && r <= '9' }
+func isAlnum(r rune) bool { return isAlpha(r) || isDigit(r) }
+
+// 記号を挟んで数字から英字へ、または英字から数字へ繰り上げようとしているか
+func crossesKind(last, c rune) bool {
+	return isAlpha(last) && isDigit(c) || isDigit(last) && isAlpha(c)
+}
+
+// 数字だけからなる文字列か
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !isDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// 文字コードで次の文字。サロゲートの範囲は飛ばす
+func nextRune(r rune) rune {
+	r++
+	if 0xD800 <= r && r <= 0xDFFF {
+		r = 0xE000
+	}
+	return r
+}
+
+// 文字コードで前の文字。サロゲートの範囲は飛ばす
+func prevRune(r rune) rune {
+	r--
+	if 0xD800 <= r && r <= 0xDFFF {
+		r = 0xD7FF
+	}
+	return r
+}
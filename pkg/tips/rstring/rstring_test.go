@@ -0,0 +1,154 @@
+package rstring
+
+import (
+	"reflect"
+	"testing"
+)
+
+// RubyのドキュメントとテストにあるString#succの例
+var succTests = []struct {
+	in, want string
+}{
+	{"", ""},
+	{"a", "b"},
+	{"y", "z"},
+	{"az", "ba"},
+	{"zz", "aaa"},
+	{"Az", "Ba"},
+	{"Zz", "AAa"},
+	{"a9", "b0"},
+	{"a99", "b00"},
+	{"A99", "B00"},
+	{"A099", "A100"},
+	{"zz99", "aaa00"},
+	{"abc", "abd"},
+	{"abcd", "abce"},
+	{"123", "124"},
+	{"999", "1000"},
+	{"1.999", "2.000"},
+	{"1.9.9", "2.0.0"},
+	{"No.9", "No.10"},
+	{"a-9", "a-10"},
+	{"-9", "-10"},
+	{"THX1138", "THX1139"},
+	{"<<koala>>", "<<koalb>>"},
+	{"1999zzz", "2000aaa"},
+	{"ZZZ9999", "AAAA0000"},
+	{"ZZZZ999", "AAAAA000"},
+	{"zz99zz99", "aaa00aa00"},
+	{"99zz99zz", "100aa00aa"},
+	{"**", "*+"},
+	{"***", "**+"},
+	{" ", "!"},
+
+	// 英数字はASCIIだけ
+	{"あ", "ぃ"},
+	{"あz", "あaa"},
+	{"ｚ", "｛"},
+	{"Ｇo９", "Ｇp９"},
+	{"９", "："},
+	{"é", "ê"},
+
+	// 文字コードで進めるときはサロゲートを飛ばす
+	{"퟿", ""},
+}
+
+func TestSucc(t *testing.T) {
+	for _, tt := range succTests {
+		if got := Succ(tt.in); got != tt.want {
+			t.Errorf("Succ(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPred(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"abce", "abcd"},
+		{"B00", "A99"},
+		{"10", "9"},
+		{"AAa", "Zz"},
+		{"2000aaa", "1999zzz"},
+		{"AAAA0000", "ZZZ9999"},
+		{"a-10", "a-9"},
+		{"<<koalb>>", "<<koala>>"},
+		{"**+", "***"},
+		{"ぃ", "あ"},
+		{"ａａ", "ａ｀"},
+		{"あaa", "あz"},
+		{"", "퟿"},
+
+		// 次がこれになる文字列はない
+		{"a", ""},
+		{"0", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Pred(tt.in); got != tt.want {
+			t.Errorf("Pred(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// Succ()の例は、Pred()で戻すと元の文字列か、それより短い同じ次を持つ文字列になる
+func TestPredSucc(t *testing.T) {
+	for _, tt := range succTests {
+		if tt.in == "" {
+			continue
+		}
+		p := Pred(tt.want)
+		if p == "" || Succ(p) != tt.want || len(p) > len(tt.in) {
+			t.Errorf("Pred(%q) = %q, want %q or a shorter string with the same Succ", tt.want, p, tt.in)
+		}
+	}
+}
+
+// RubyのString#uptoのテストとドキュメントにある例
+func TestUpto(t *testing.T) {
+	tests := []struct {
+		from, to  string
+		exclusive bool
+		want      []string
+	}{
+		{"a", "e", false, []string{"a", "b", "c", "d", "e"}},
+		{"a", "e", true, []string{"a", "b", "c", "d"}},
+		{"a", "a", false, []string{"a"}},
+		{"a", "a", true, nil},
+		{"b", "a", false, nil},
+		{"9", "A", false, []string{"9", ":", ";", "<", "=", ">", "?", "@", "A"}},
+		{"9", "11", false, []string{"9", "10", "11"}},
+		{"9", "11", true, []string{"9", "10"}},
+		{"25", "5", false, nil},
+		{"07", "11", false, []string{"07", "08", "09", "10", "11"}},
+		{"a8", "b2", false, []string{"a8", "a9", "b0", "b1", "b2"}},
+		{"a8", "b2", true, []string{"a8", "a9", "b0", "b1"}},
+		{"aa", "ab", true, []string{"aa"}},
+		{"xy", "ab", false, nil},
+		{"y", "ab", false, nil},
+		{"az", "bb", false, []string{"az", "ba", "bb"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		Upto(tt.from, tt.to, tt.exclusive, func(s string) { got = append(got, s) })
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Upto(%q, %q, %v) = %q, want %q", tt.from, tt.to, tt.exclusive, got, tt.want)
+		}
+	}
+}
+
+// Rubyのテストと同じく、"aa"から"zz"までは2文字の組み合わせを全部たどる
+func TestUptoAll(t *testing.T) {
+	n := 0
+	want := "aa"
+	Upto("aa", "zz", false, func(s string) {
+		if s != want {
+			t.Fatalf("got %q, want %q", s, want)
+		}
+		want = Succ(want)
+		n++
+	})
+	if n != 26*26 {
+		t.Errorf("Upto(\"aa\", \"zz\") yielded %d strings, want %d", n, 26*26)
+	}
+}
@@ -0,0 +1,229 @@
+package rstring
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RubyのString#tr、tr_s、delete、squeeze、countです。
+//
+// 文字の指定には次の書き方が使えます。1文字ずつruneで扱うので、日本語にも使えます。
+//
+//   - a-z: 範囲。z-a のように逆順だとエラーです
+//   - ^aeiou: 先頭の^で、書いた文字以外(tr の to では使えません)
+//   - \-、\^、\\: \の次の文字はそのまま。先頭と末尾の-もそのままです
+//
+// 正規表現と同じく、指定を先に解釈した Translator や CharSet を作ってから使います。
+
+// Translator はRubyのString#trのように、from の文字を to の同じ位置の文字に置き換えます。
+type Translator struct {
+	from, to charSpec
+	del      bool // to が空なので、置き換えずに取り除く
+}
+
+// NewTranslator は from の文字を to の文字に置き換える Translator を作ります。
+// to が from より短ければ、to の最後の文字を繰り返したものとします。to が空なら取り除きます。
+func NewTranslator(from, to string) (*Translator, error) {
+	f, err := parseCharSpec(from, true)
+	if err != nil {
+		return nil, err
+	}
+	t, err := parseCharSpec(to, false)
+	if err != nil {
+		return nil, err
+	}
+	return &Translator{from: f, to: t, del: len(t.ranges) == 0}, nil
+}
+
+// MustTranslator は NewTranslator() と同じですが、指定が正しくなければpanicします。
+func MustTranslator(from, to string) *Translator {
+	t, err := NewTranslator(from, to)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Tr は s の文字を置き換えます。
+func (t *Translator) Tr(s string) string {
+	return t.tr(s, false)
+}
+
+// TrS は Tr() のあと、置き換えてできた同じ文字の並びを1文字にします。RubyのString#tr_sです。
+func (t *Translator) TrS(s string) string {
+	return t.tr(s, true)
+}
+
+func (t *Translator) tr(s string, squeeze bool) string {
+	var b strings.Builder
+	last := rune(-1) // 直前に置き換えてできた文字
+	for _, r := range s {
+		i := t.from.index(r)
+		replaced := t.from.negate == (i < 0)
+		if replaced && t.del {
+			continue
+		}
+		c := r
+		if replaced && t.from.negate {
+			c = t.to.last()
+		} else if replaced {
+			c = t.to.at(i)
+		}
+		if replaced && squeeze && c == last {
+			continue
+		}
+		if replaced {
+			last = c
+		} else {
+			last = -1
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// CharSet はRubyのString#delete、squeeze、countに渡す文字の指定です。
+// 複数の指定から作ると、そのすべてに含まれる文字を表します。指定がなければすべての文字です。
+type CharSet struct {
+	specs []charSpec
+}
+
+// NewCharSet は specs のすべてに含まれる文字の CharSet を作ります。
+func NewCharSet(specs ...string) (*CharSet, error) {
+	cs := &CharSet{}
+	for _, s := range specs {
+		c, err := parseCharSpec(s, true)
+		if err != nil {
+			return nil, err
+		}
+		cs.specs = append(cs.specs, c)
+	}
+	return cs, nil
+}
+
+// MustCharSet は NewCharSet() と同じですが、指定が正しくなければpanicします。
+func MustCharSet(specs ...string) *CharSet {
+	cs, err := NewCharSet(specs...)
+	if err != nil {
+		panic(err)
+	}
+	return cs
+}
+
+// Contains は r が含まれるかを返します。
+func (cs *CharSet) Contains(r rune) bool {
+	for _, c := range cs.specs {
+		if !c.contains(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Delete は s から含まれる文字を取り除きます。
+func (cs *CharSet) Delete(s string) string {
+	return strings.Map(func(r rune) rune {
+		if cs.Contains(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// Squeeze は s の中で、含まれる文字が続くところを1文字にします。
+func (cs *CharSet) Squeeze(s string) string {
+	var b strings.Builder
+	last := rune(-1)
+	for _, r := range s {
+		if r == last && cs.Contains(r) {
+			continue
+		}
+		last = r
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Count は s の中の含まれる文字を数えます。
+func (cs *CharSet) Count(s string) int {
+	n := 0
+	for _, r := range s {
+		if cs.Contains(r) {
+			n++
+		}
+	}
+	return n
+}
+
+// 文字の範囲
+type charRange struct{ lo, hi rune }
+
+// 1つの文字の指定
+type charSpec struct {
+	ranges []charRange // 書いた順
+	negate bool        // 先頭が^
+}
+
+// s を charSpec にする。canNegate が false なら先頭の^もただの文字
+func parseCharSpec(s string, canNegate bool) (charSpec, error) {
+	var c charSpec
+	rs := []rune(s)
+	i := 0
+	if canNegate && len(rs) > 1 && rs[0] == '^' {
+		c.negate = true
+		i++
+	}
+	for i < len(rs) {
+		if rs[i] == '\\' && i+1 < len(rs) {
+			i++
+		}
+		lo := rs[i]
+		i++
+		if i+1 < len(rs) && rs[i] == '-' {
+			hi := rs[i+1]
+			if lo > hi {
+				return charSpec{}, fmt.Errorf("invalid range %q in string transliteration", string([]rune{lo, '-', hi}))
+			}
+			c.ranges = append(c.ranges, charRange{lo, hi})
+			i += 2
+			continue
+		}
+		c.ranges = append(c.ranges, charRange{lo, lo})
+	}
+	return c, nil
+}
+
+// r が範囲を展開した中で何番目か。何度も出てくるなら最後の位置、なければ -1
+// (^は考えない)
+func (c charSpec) index(r rune) int {
+	i, found := 0, -1
+	for _, cr := range c.ranges {
+		if cr.lo <= r && r <= cr.hi {
+			found = i + int(r-cr.lo)
+		}
+		i += int(cr.hi-cr.lo) + 1
+	}
+	return found
+}
+
+// 範囲を展開した中で i 番目の文字。足りなければ最後の文字
+func (c charSpec) at(i int) rune {
+	for _, cr := range c.ranges {
+		if n := int(cr.hi-cr.lo) + 1; i >= n {
+			i -= n
+		} else {
+			return cr.lo + rune(i)
+		}
+	}
+	return c.last()
+}
+
+// 範囲を展開したときの最後の文字
+func (c charSpec) last() rune {
+	return c.ranges[len(c.ranges)-1].hi
+}
+
+// r が指定に含まれるか
+func (c charSpec) contains(r rune) bool {
+	return (c.index(r) >= 0) != c.negate
+}
@@ -0,0 +1,122 @@
+package rstring
+
+import "testing"
+
+// RubyのドキュメントとテストにあるString#tr、tr_sの例
+func TestTr(t *testing.T) {
+	tests := []struct {
+		s, from, to string
+		want, wantS string
+	}{
+		{"hello", "el", "ip", "hippo", "hipo"},
+		{"hello", "aeiou", "*", "h*ll*", "h*ll*"},
+		{"hello", "aeiou", "AA*", "hAll*", "hAll*"},
+		{"hello", "a-y", "b-z", "ifmmp", "ifmp"},
+		{"hello", "^aeiou", "*", "*e**o", "*e*o"},
+		{"hello", "el", "", "ho", "ho"},
+		{"hello", "l", "r", "herro", "hero"},
+		{"hello", "el", "-", "h---o", "h-o"},
+		{"aabbcc", "^b", "*", "**bb**", "*bb*"},
+		{"hello-world", `\-`, "_", "hello_world", "hello_world"},
+		{"hello^world", `\^`, "-", "hello-world", "hello-world"},
+		{"a-b", "a-", "xy", "xyb", "xyb"},
+		{"^", "^", "x", "x", "x"},
+		{"abc", "abc", "^", "^^^", "^"},
+		{"こんにちは", "ぁ-ん", "ァ-ン", "コンニチハ", "コンニチハ"},
+		{"１２３円", "０-９", "0-9", "123円", "123円"},
+	}
+	for _, tt := range tests {
+		tr, err := NewTranslator(tt.from, tt.to)
+		if err != nil {
+			t.Errorf("NewTranslator(%q, %q): %v", tt.from, tt.to, err)
+			continue
+		}
+		if got := tr.Tr(tt.s); got != tt.want {
+			t.Errorf("tr(%q, %q, %q) = %q, want %q", tt.s, tt.from, tt.to, got, tt.want)
+		}
+		if got := tr.TrS(tt.s); got != tt.wantS {
+			t.Errorf("tr_s(%q, %q, %q) = %q, want %q", tt.s, tt.from, tt.to, got, tt.wantS)
+		}
+	}
+}
+
+func TestDeleteSqueezeCount(t *testing.T) {
+	tests := []struct {
+		s       string
+		specs   []string
+		del     string
+		squeeze string
+		count   int
+	}{
+		{"hello", []string{"l", "lo"}, "heo", "helo", 2},
+		{"hello", []string{"lo"}, "he", "helo", 3},
+		{"hello", []string{"aeiou", "^e"}, "hell", "hello", 1},
+		{"hello", []string{"ej-m"}, "ho", "helo", 3},
+		{"090-1234-5678", []string{"^0-9"}, "09012345678", "090-1234-5678", 2},
+		{"yellow moon", nil, "", "yelow mon", 11},
+		{"  now   is  the", []string{" "}, "nowisthe", " now is the", 7},
+		{"putters shoot balls", []string{"m-z"}, "e h ball", "puters shot balls", 11},
+		{"hello world", []string{"lo", "o"}, "hell wrld", "hello world", 2},
+		{"hello world", []string{"hello", "^l"}, "ll wrld", "hello world", 4},
+		{"hello^world", []string{`\^aeiou`}, "hllwrld", "hello^world", 4},
+		{"hello-world", []string{`a\-eo`}, "hllwrld", "hello-world", 4},
+		{"すもももももももものうち", []string{"も"}, "すのうち", "すものうち", 8},
+		{"ええーーっ！！", []string{"ー！"}, "ええっ", "ええーっ！", 4},
+	}
+	for _, tt := range tests {
+		cs, err := NewCharSet(tt.specs...)
+		if err != nil {
+			t.Errorf("NewCharSet(%q): %v", tt.specs, err)
+			continue
+		}
+		if got := cs.Delete(tt.s); got != tt.del {
+			t.Errorf("delete(%q, %q) = %q, want %q", tt.s, tt.specs, got, tt.del)
+		}
+		if got := cs.Squeeze(tt.s); got != tt.squeeze {
+			t.Errorf("squeeze(%q, %q) = %q, want %q", tt.s, tt.specs, got, tt.squeeze)
+		}
+		if got := cs.Count(tt.s); got != tt.count {
+			t.Errorf("count(%q, %q) = %d, want %d", tt.s, tt.specs, got, tt.count)
+		}
+	}
+}
+
+// 逆順の範囲はRubyと同じくエラーにする
+func TestInvalidRange(t *testing.T) {
+	const want = `invalid range "z-a" in string transliteration`
+	if _, err := NewTranslator("z-a", "*"); err == nil || err.Error() != want {
+		t.Errorf("NewTranslator(\"z-a\", \"*\") error = %v, want %q", err, want)
+	}
+	if _, err := NewTranslator("a", "9-0"); err == nil {
+		t.Error("NewTranslator(\"a\", \"9-0\"): no error")
+	}
+	if _, err := NewCharSet("a-c", "ん-あ"); err == nil {
+		t.Error("NewCharSet(\"a-c\", \"ん-あ\"): no error")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("MustCharSet(\"z-a\") did not panic")
+		}
+	}()
+	MustCharSet("z-a")
+}
+
+func TestSwapcase(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"Hello", "hELLO"},
+		{"cYbEr_PuNk11", "CyBeR_pUnK11"},
+		{"i lOVE gOLANG", "I Love Golang"},
+		{"Ｇｏ言語", "ｇＯ言語"},
+		{"ΑβΓ", "αΒγ"},
+		{"straße", "STRASSE"},
+		{"ǅ", "ǆ"},
+	}
+	for _, tt := range tests {
+		if got := Swapcase(tt.in); got != tt.want {
+			t.Errorf("Swapcase(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
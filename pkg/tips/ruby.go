@@ -0,0 +1,66 @@
+package tips
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SetRuby はTipsに、逆引きRubyで対応するメソッドを登録します。
+// methods はIDから "Array#assoc" や "File.open" のようなメソッド名への対応で、
+// Register() の後に呼びます。
+func SetRuby(methods map[string][]string) {
+	for id, ms := range methods {
+		t, ok := byID[id]
+		if !ok {
+			panic(fmt.Sprintf("tips: %s is not registered", id))
+		}
+		t.Ruby = ms
+	}
+}
+
+// LookupRuby はRubyのメソッド名からTipsを目次の順に探します。
+//
+// "Array#assoc" のように書けばそのメソッドを、"assoc" のようにクラスを省けば
+// 同じ名前のメソッド全てを探します。英字の大小は区別しません。
+func LookupRuby(method string) []*Tip {
+	var ts []*Tip
+	for _, t := range All() {
+		for _, m := range t.Ruby {
+			if rubyMatch(m, method) {
+				ts = append(ts, t)
+				break
+			}
+		}
+	}
+	return ts
+}
+
+func rubyMatch(m, method string) bool {
+	if strings.EqualFold(m, method) {
+		return true
+	}
+	if strings.ContainsAny(method, "#.") {
+		return false
+	}
+	if i := strings.LastIndexAny(m, "#."); i >= 0 {
+		m = m[i+1:]
+	}
+	return strings.EqualFold(m, method)
+}
+
+// RubyMethods は登録された全てのRubyのメソッド名を並べて返します。
+func RubyMethods() []string {
+	seen := map[string]bool{}
+	var ms []string
+	for _, t := range All() {
+		for _, m := range t.Ruby {
+			if !seen[m] {
+				seen[m] = true
+				ms = append(ms, m)
+			}
+		}
+	}
+	sort.Strings(ms)
+	return ms
+}
@@ -0,0 +1,157 @@
+package tips
+
+import (
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ファイルやディレクトリのTipsは test.txt を書き換えたり sample を doc に名前を変えたり、
+// カレントディレクトリを移動したりします。開発中のマシンで気軽に実行できるように、
+// 一時ディレクトリを用意してその中で実行します。
+
+// 一時ディレクトリに最初から置いておくファイル。etc 以下は /etc の代わりです。
+var skeleton = map[string]string{
+	"etc/hostname":           "golangtips\n",
+	"etc/hosts":              "127.0.0.1\tlocalhost\n::1\tlocalhost ip6-localhost ip6-loopback\n",
+	"etc/passwd":             "root:x:0:0:root:/root:/bin/bash\ngopher:x:1000:1000:Gopher:/home/gopher:/bin/bash\n",
+	"etc/group":              "root:x:0:\ngopher:x:1000:\n",
+	"etc/shells":             "/bin/sh\n/bin/bash\n",
+	"etc/ssh/ssh_config":     "Host *\n    SendEnv LANG LC_*\n",
+	"etc/ssh/sshd_config":    "PermitRootLogin no\n",
+	"etc/network/interfaces": "auto lo\niface lo inet loopback\n",
+	"sample/README":          "dir_Rename で doc に名前が変わります\n",
+}
+
+// ソースのファイル名 -> そのパッケージのファイル
+var fixtures = map[string]fs.FS{}
+
+// ソースのファイル名 -> ファイル名 -> パーミッション
+var fixtureModes = map[string]map[string]fs.FileMode{}
+
+// AddFixtures はサンドボックスに置くファイルを登録します。
+// tips_file の test.txt のように、Tipsが読むファイルを持つパッケージのinit()から
+// Register() と同じファイル名で呼ばれます。
+//
+// embed.FS はパーミッションを持たないので、置いたファイルは umask に従った 0666 になります。
+// 違うパーミッションから始めたいファイルは modes に書きます。
+func AddFixtures(filename string, fsys fs.FS, modes map[string]fs.FileMode) {
+	fixtures[filename] = fsys
+	fixtureModes[filename] = modes
+}
+
+// FixtureFiles はファイルを登録したパッケージのソースのファイル名を返します。
+// サンドボックスの中身はリンクされたパッケージで変わるので、
+// 同じ中身にしたい場合はこれらのパッケージをimportしてください。
+func FixtureFiles() []string {
+	var files []string
+	for f := range fixtures {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// Sandbox はTipsを実行するための使い捨てのディレクトリです。
+type Sandbox struct {
+	Dir string
+}
+
+// NewSandbox は一時ディレクトリを作り、登録されたファイルと etc などの雛形を置きます。
+// 使い終わったら Close() で削除してください。
+func NewSandbox() (*Sandbox, error) {
+	dir, err := ioutil.TempDir("", "golangtips")
+	if err != nil {
+		return nil, err
+	}
+	s := &Sandbox{Dir: dir}
+	if err := s.seed(); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Sandbox) seed() error {
+	for name, body := range skeleton {
+		if err := s.writeFile(name, []byte(body)); err != nil {
+			return err
+		}
+	}
+	for _, filename := range FixtureFiles() {
+		fsys := fixtures[filename]
+		err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			b, err := fs.ReadFile(fsys, path)
+			if err != nil {
+				return err
+			}
+			if err := s.writeFile(path, b); err != nil {
+				return err
+			}
+			if mode, ok := fixtureModes[filename][path]; ok {
+				return os.Chmod(filepath.Join(s.Dir, filepath.FromSlash(path)), mode)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Sandbox) writeFile(name string, b []byte) error {
+	path := filepath.Join(s.Dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0666)
+}
+
+// Run はカレントディレクトリをサンドボックスに移して f() を実行し、元に戻します。
+// f() がpanicしても、カレントディレクトリを戻してからpanicを続けます。
+// f() からは、引数なしで起動したプログラムのように os.Args がプログラム名だけに見えます。
+//
+// カレントディレクトリはプロセス全体で共有なので、複数のRunを並行して呼ばないでください。
+func (s *Sandbox) Run(f func()) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if err := os.Chdir(s.Dir); err != nil {
+		return err
+	}
+	defer os.Chdir(wd)
+	asProgram(f)
+	return nil
+}
+
+// asProgram は f() を引数なしで起動したプログラムのように実行します。
+// golangtips run file_Filter の run や file_Filter をTipsが自分の引数だと思わないように、
+// os.Args をプログラム名だけにして、終わったら元に戻します。
+func asProgram(f func()) {
+	args := os.Args
+	os.Args = args[:1:1]
+	defer func() { os.Args = args }()
+	f()
+}
+
+// Close はサンドボックスを中身ごと削除します。
+func (s *Sandbox) Close() error {
+	return os.RemoveAll(s.Dir)
+}
+
+// RunInSandbox は新しいサンドボックスで f() を実行し、終わったら削除します。
+func RunInSandbox(f func()) error {
+	s, err := NewSandbox()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	return s.Run(f)
+}
@@ -0,0 +1,104 @@
+package tips
+
+import (
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Snippet はTipsのコードを全コピペで動作するプログラムにしたものを返します。
+//
+// 冒頭に package main と、InferImports() で求めたimport文を追加し、
+// Tipsの関数名を main に置き換えます。コメントに書いた // import "hogehoge" は取り除きます。
+// 同じファイルの別のブロックにある補助関数を使っていれば、末尾に追加します。
+func (t *Tip) Snippet() string {
+	if t.Stub() {
+		return ""
+	}
+	code := importHintLine.ReplaceAllString(t.Code, "")
+	if hs := t.Helpers(); len(hs) > 0 {
+		body := strings.TrimRight(code, "\n")
+		code = body + "\n\n" + strings.Join(hs, "\n\n") + "\n" + code[len(body):]
+	}
+
+	// 最初の関数名をmainに置き換える
+	re := regexp.MustCompile(`func( )*` + regexp.QuoteMeta(t.ID) + `\(`)
+	if loc := re.FindStringIndex(code); loc != nil {
+		code = code[:loc[0]] + "func main(" + code[loc[1]:]
+	}
+
+	header := "package main\n\n"
+	if is := t.InferImports(); len(is) > 0 {
+		header += importBlock(is) + "\n"
+	}
+	return header + strings.TrimLeft(code, "\n")
+}
+
+// // import "hogehoge" だけの行
+var importHintLine = regexp.MustCompile(`(?m)^\s*//\s*import\s+.+\n`)
+
+// Helpers はTipsのコードが使っている、同じファイルの別のブロックにある
+// 関数・型・変数の宣言をファイルの順に返します。型にはそのメソッドも含みます。
+func (t *Tip) Helpers() []string {
+	inBlock := func(d *decl) bool { return d.offset >= t.start && d.offset < t.end }
+
+	defined := map[string]bool{}
+	for _, d := range t.decls {
+		if inBlock(d) {
+			for _, n := range d.names {
+				defined[n] = true
+			}
+		}
+	}
+
+	used := map[*decl]bool{}
+	queue := unresolved(t.Code)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if defined[name] {
+			continue
+		}
+		defined[name] = true
+		for _, d := range t.decls {
+			if used[d] || inBlock(d) || !(d.recv == name || contains(d.names, name)) {
+				continue
+			}
+			used[d] = true
+			queue = append(queue, unresolved(d.text)...)
+		}
+	}
+
+	var hs []string
+	for _, d := range t.decls {
+		if used[d] {
+			hs = append(hs, d.text)
+		}
+	}
+	return hs
+}
+
+func contains(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// OfflineEnv は go build がネットワークに出ないように、モジュールキャッシュをプロキシにした環境変数を返します。
+// serve の Run edited でコピペ用のコードをビルドするときに使います。
+func OfflineEnv() []string {
+	env := os.Environ()
+	b, err := exec.Command("go", "env", "GOMODCACHE").Output()
+	if err != nil {
+		return append(env, "GOPROXY=off")
+	}
+	cache := filepath.Join(strings.TrimSpace(string(b)), "cache", "download")
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(cache)}
+	return append(env, "GOPROXY="+u.String(), "GOSUMDB=off", "GOFLAGS=-mod=mod")
+}
@@ -0,0 +1,186 @@
+package tips
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Expectation はコード中の // => "期待値" の注記です。
+type Expectation struct {
+	Line int    // ファイル上の行番号
+	Want string // 期待される出力の1行
+}
+
+// Mismatch は注記と実際の出力が食い違った箇所です。
+type Mismatch struct {
+	Expectation
+	Got string // 実際の出力。出力が足りなければ空
+}
+
+// Result はTipsを実行して注記と突き合わせた結果です。
+type Result struct {
+	Tip        *Tip
+	Output     string
+	Checked    int // 突き合わせた注記の数
+	Mismatches []Mismatch
+	Err        error // 実行中のpanicやサンドボックスのエラー
+}
+
+// OK は食い違いもpanicもなければtrueを返します。
+func (r *Result) OK() bool {
+	return r.Err == nil && len(r.Mismatches) == 0
+}
+
+// // => "x", //=>x, // = >"x", // -> "x" などの揺れを許します。
+var annotation = regexp.MustCompile(`^(.*?)//\s*(=\s*>|->|=")\s*(.*)$`)
+
+// 代入文の行の注記は出力ではなく値の説明なので数えません。
+var assignment = regexp.MustCompile(`^[\w.,\[\] ]+:?=[^=]`)
+
+// Expectations はTipsのコードから出力についての注記を順に取り出します。
+// 注記は出力する文の行末か、その後ろの単独のコメント行に書かれているものとします。
+func (t *Tip) Expectations() []Expectation {
+	var es []Expectation
+	for i, l := range strings.Split(t.Code, "\n") {
+		m := annotation.FindStringSubmatch(l)
+		if m == nil {
+			continue
+		}
+		code := strings.TrimSpace(m[1])
+		if assignment.MatchString(code) {
+			continue
+		}
+		want := strings.TrimSpace(m[3])
+		if m[2] == `="` {
+			want = `"` + want
+		}
+		es = append(es, Expectation{Line: t.CodeLine(i), Want: unquote(want)})
+	}
+	return es
+}
+
+// "..." で始まっていればその中身を、そうでなければ全体を返す。
+// //=>"3" Roundはないらしい のような後ろの説明は捨てます。
+func unquote(s string) string {
+	if !strings.HasPrefix(s, `"`) {
+		return s
+	}
+	if i := strings.Index(s[1:], `"`); i >= 0 {
+		return s[1 : i+1]
+	}
+	return s[1:]
+}
+
+// Capture は f() が標準出力に書いた内容を返します。f()がpanicした場合はそれをerrorで返します。
+func Capture(f func()) (out string, err error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+	done := make(chan string)
+	go func() {
+		b, _ := ioutil.ReadAll(r)
+		r.Close()
+		done <- string(b)
+	}()
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+		os.Stdout = stdout
+		w.Close()
+		out = <-done
+	}()
+	f()
+	return "", nil
+}
+
+// Verify はTipsを実行し、出力と // => の注記を突き合わせます。
+// 入力を待つTipsには SetInputs() で登録したスクリプトを流し込みます。
+//
+// loc を指定すると実行中の time.Local をそれに置き換えます。
+// 注記の多くは日本時間(+0900 JST)で書かれているので、
+// time.FixedZone("JST", 9*60*60) を渡すとタイムゾーンによる違いがなくなります。
+func Verify(t *Tip, loc *time.Location) *Result {
+	r := &Result{Tip: t}
+	if t.Func == nil {
+		return r
+	}
+	if loc != nil {
+		local := time.Local
+		time.Local = loc
+		defer func() { time.Local = local }()
+	}
+	var err error
+	r.Output, r.Err = Capture(func() { err = t.RunUnattended() })
+	if r.Err == nil {
+		r.Err = err
+	}
+
+	es := t.Expectations()
+	r.Checked = len(es)
+	r.Mismatches = align(es, strings.Split(strings.TrimRight(r.Output, "\n"), "\n"))
+	return r
+}
+
+// align は注記と出力行を対応付け、食い違いを返します。
+//
+// 注記のない出力行は読み飛ばしてよく(コスト0)、注記と違う行への対応付けをコスト1、
+// 対応する行のない注記をコスト2として、diffと同じ要領でコスト最小の対応を探します。
+// 注記のある文は何か出力しているはずなので、行が足りないよりは値違いとみなします。
+func align(es []Expectation, lines []string) []Mismatch {
+	n, m := len(es), len(lines)
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " \t\r")
+	}
+
+	// cost[i][j]: es[i:] と lines[j:] の最小コスト
+	cost := make([][]int, n+1)
+	for i := range cost {
+		cost[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		cost[i][m] = cost[i+1][m] + 2
+		for j := m - 1; j >= 0; j-- {
+			c := cost[i+1][j+1]
+			if es[i].Want != lines[j] {
+				c++
+			}
+			if skip := cost[i][j+1]; skip < c {
+				c = skip
+			}
+			if missing := cost[i+1][j] + 2; missing < c {
+				c = missing
+			}
+			cost[i][j] = c
+		}
+	}
+
+	var ms []Mismatch
+	i, j := 0, 0
+	for i < n {
+		switch {
+		case j < m && es[i].Want == lines[j] && cost[i][j] == cost[i+1][j+1]:
+			i, j = i+1, j+1
+		case j+1 < m && cost[i][j] == cost[i][j+1]:
+			// 読み飛ばしても同じコストなら、食い違いはなるべく後ろの行に対応付ける
+			j++
+		case j < m && cost[i][j] == cost[i+1][j+1]+1:
+			ms = append(ms, Mismatch{es[i], lines[j]})
+			i, j = i+1, j+1
+		case j < m && cost[i][j] == cost[i][j+1]:
+			j++
+		default:
+			ms = append(ms, Mismatch{es[i], ""})
+			i++
+		}
+	}
+	return ms
+}
@@ -0,0 +1,105 @@
+package tips
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpectations(t *testing.T) {
+	tests := []struct {
+		code string
+		want []string // nilなら注記として数えない
+	}{
+		{`fmt.Println(x) // => "3"`, []string{"3"}},
+		{`fmt.Println(x) //=>3`, []string{"3"}},
+		{`fmt.Println(x) // = >"3"`, []string{"3"}},
+		{`fmt.Println(x) // -> "3"`, []string{"3"}},
+		{`fmt.Println(x) //="3"`, []string{"3"}},
+		{`fmt.Println(x) //=>"3" Roundはないらしい`, []string{"3"}},
+		{`fmt.Println(x) // => "a b "`, []string{"a b "}},
+		{`fmt.Println(x) // => ""`, []string{""}},
+		{`fmt.Println(x) // => "unterminated`, []string{"unterminated"}},
+		{`fmt.Println(a == b) // => true`, []string{"true"}},
+		{`	// => "on its own line"`, []string{"on its own line"}},
+		{`fmt.Println(x) // comment`, nil},
+		{`fmt.Println(x) // a == b`, nil},
+		{`x := 3 // => 3`, nil},
+		{`a, b = f() // => 1, 2`, nil},
+		{`s[0] = "x" // => "x"`, nil},
+		{`t.x := 1 //=>1`, nil},
+	}
+	for _, tt := range tests {
+		tip := &Tip{Code: tt.code}
+		var got []string
+		for _, e := range tip.Expectations() {
+			got = append(got, e.Want)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
+
+// 注記の行番号はコードの何行目かになる
+func TestExpectationsLine(t *testing.T) {
+	tip := &Tip{Code: strings.Join([]string{
+		`x := 3 // => 3`,
+		`fmt.Println(x) // => "3"`,
+		``,
+		`fmt.Println(x + 1)`,
+		`// => "4"`,
+	}, "\n")}
+	want := []Expectation{{Line: 1, Want: "3"}, {Line: 4, Want: "4"}}
+	if got := tip.Expectations(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestAlign(t *testing.T) {
+	tests := []struct {
+		name  string
+		want  []string // 注記
+		lines []string // 出力
+		miss  []Mismatch
+	}{
+		{"same", []string{"a", "b"}, []string{"a", "b"}, nil},
+		{"no annotations", nil, []string{"a", "b"}, nil},
+		{"trailing spaces", []string{"a"}, []string{"a \t\r"}, nil},
+		{"extra lines", []string{"a", "b"},
+			[]string{"x", "a", "y", "z", "b", "w"}, nil},
+		{"wrong value", []string{"a", "b", "c"},
+			[]string{"a", "B", "c"},
+			[]Mismatch{{Expectation{1, "b"}, "B"}}},
+		{"missing line", []string{"a", "b", "c"},
+			[]string{"a", "c"},
+			[]Mismatch{{Expectation{1, "b"}, ""}}},
+		{"no output", []string{"a", "b"},
+			[]string{""},
+			[]Mismatch{{Expectation{0, "a"}, ""}, {Expectation{1, "b"}, ""}}},
+		{"reordered", []string{"a", "b", "c"},
+			[]string{"b", "a", "c"},
+			[]Mismatch{{Expectation{1, "b"}, ""}}},
+		{"last line", []string{"a", "b"},
+			[]string{"a", "B"},
+			[]Mismatch{{Expectation{1, "b"}, "B"}}},
+		{"last line with extra lines", []string{"a", "b"},
+			[]string{"a", "x", "B"},
+			[]Mismatch{{Expectation{1, "b"}, "B"}}},
+		{"last line missing", []string{"a", "b"},
+			[]string{"a"},
+			[]Mismatch{{Expectation{1, "b"}, ""}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var es []Expectation
+			for i, w := range tt.want {
+				es = append(es, Expectation{Line: i, Want: w})
+			}
+			got := align(es, append([]string(nil), tt.lines...))
+			if !reflect.DeepEqual(got, tt.miss) {
+				t.Errorf("align(%q, %q) = %v, want %v", tt.want, tt.lines, got, tt.miss)
+			}
+		})
+	}
+}
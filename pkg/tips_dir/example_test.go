@@ -0,0 +1,81 @@
+// Code generated by make_examples; DO NOT EDIT.
+
+package tips_dir
+
+import (
+	"time"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+	_ "github.com/ashitani/golangtips/pkg/tips_file"
+)
+
+// 次のTipsはExampleにできないので生成していません。
+//
+//   dir_Pwd: /etc を使う ("/etc")
+//   dir_Glob: /etc を使う ("/etc/", "/etc/*")
+//   dir_Glob_go1_16: /etc を使う ("/etc/", "/etc/*")
+//   dir_IsDir: /etc を使う ("/etc")
+//   dir_ShowFullPath: /etc を使う ("/etc/")
+//   dir_ShowFullPath_go1_16: /etc を使う ("/etc/")
+
+func init() {
+	time.Local = time.FixedZone("JST", 9*60*60)
+}
+
+// ディレクトリの作成
+func Example_makeDir() {
+	tips.RunInSandbox(dir_MakeDir)
+	// Output:
+	// <nil>
+}
+
+// ディレクトリの削除
+func Example_removeDir() {
+	tips.RunInSandbox(dir_RemoveDir)
+	// Output:
+	// remove ./tmp: no such file or directory
+}
+
+// 中身が空でないディレクトリを削除する
+func Example_removeDirAll() {
+	tips.RunInSandbox(dir_RemoveDirAll)
+	// Output:
+	// <nil>
+}
+
+// ディレクトリ名を変更する
+func Example_rename() {
+	tips.RunInSandbox(dir_Rename)
+	// Output:
+	// <nil>
+}
+
+// ディレクトリ中のファイル一覧を取得する
+func Example_getFileList() {
+	tips.RunInSandbox(dir_GetFileList)
+	// Output:
+	// etc
+	// fmtTxt.txt
+	// foo.csv
+	// sample
+	// test.txt
+}
+
+// ディレクトリ中のファイル一覧を取得する (Go 1.16以降)
+func Example_getFileList_go116() {
+	tips.RunInSandbox(dir_GetFileList_go1_16)
+	// Output:
+	// etc
+	// fmtTxt.txt
+	// foo.csv
+	// sample
+	// test.txt
+}
+
+// ファイル名からディレクトリ部分だけを切り出す
+func Example_dirName() {
+	dir_DirName()
+	// Output:
+	// /usr/bin
+	// /etc
+}
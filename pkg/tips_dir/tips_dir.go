@@ -6,11 +6,15 @@
 package tips_dir
 
 import (
+	_ "embed"
 	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"syscall"
+
+	"github.com/ashitani/golangtips/pkg/tips"
 )
 
 //---------------------------------------------------
@@ -116,7 +120,7 @@ func dir_Pwd() {
 	fmt.Println(p)
 
 	// 変更
-	os.Chdir("/etc")
+	os.Chdir(tips.Etc("/etc"))
 
 	// 確認
 	p, _ = os.Getwd()
@@ -138,6 +142,20 @@ func dir_GetFileList() {
 	}
 }
 
+//---------------------------------------------------
+// ディレクトリ中のファイル一覧を取得する (Go 1.16以降)
+//---------------------------------------------------
+/*
+Go 1.16からはos.ReadDir()を使います。ioutil.ReadDir()と違ってファイルごとにStatしないので軽く、
+名前の順に並んだos.DirEntryが返ります。
+*/
+func dir_GetFileList_go1_16() {
+	entries, _ := os.ReadDir("./")
+	for _, e := range entries {
+		fmt.Println(e.Name())
+	}
+}
+
 //---------------------------------------------------
 // ワイルドカードにマッチしたファイル全てに処理を行う
 //---------------------------------------------------
@@ -148,13 +166,14 @@ path/filepathにGlob()があります。下記の例では全ファイルの名
 指定したフォルダを再帰的に展開したい場合には、Walk()を使います。WalkFunc()と同じ
 インターフェイスを持つ関数を指定すると、訪問先でその関数が実行されます。
 */
+// import "os"
 // import "path/filepath"
 // import "syscall"
 
 func dir_Glob() {
 
 	// 再帰なし
-	files, _ := filepath.Glob("/etc/*")
+	files, _ := filepath.Glob(tips.Etc("/etc/*"))
 	for _, f := range files {
 		printPathAndSize(f)
 	}
@@ -162,7 +181,7 @@ func dir_Glob() {
 	fmt.Println("---------")
 
 	// 再帰あり
-	filepath.Walk("/etc/", visit)
+	filepath.Walk(tips.Etc("/etc/"), visit)
 
 }
 
@@ -184,13 +203,37 @@ func printPathAndSize(path string) {
 
 }
 
+//---------------------------------------------------
+// ワイルドカードにマッチしたファイル全てに処理を行う (Go 1.16以降)
+//---------------------------------------------------
+/*
+Go 1.16からはWalk()の代わりにWalkDir()が使えます。訪問先の関数にはos.FileInfoではなく
+fs.DirEntryが渡るので、ファイルごとにStatしない分速くなります。
+*/
+func dir_Glob_go1_16() {
+
+	// 再帰なし
+	files, _ := filepath.Glob(tips.Etc("/etc/*"))
+	for _, f := range files {
+		printPathAndSize(f)
+	}
+
+	fmt.Println("---------")
+
+	// 再帰あり
+	filepath.WalkDir(tips.Etc("/etc/"), func(path string, d fs.DirEntry, err error) error {
+		printPathAndSize(path)
+		return nil
+	})
+}
+
 //---------------------------------------------------
 // ファイル名からディレクトリ部分だけを切り出す
 //---------------------------------------------------
 /*
-os/filepathのDir()を使います。
+path/filepathのDir()を使います。
 */
-// import "os/filepath"
+// import "path/filepath"
 
 func dir_DirName() {
 	fmt.Println(filepath.Dir("/usr/bin/ruby")) // => "/usr/bin"
@@ -210,7 +253,7 @@ FileInfo.Mode()でFileModeが返るのでそちらを使います。
 
 func dir_IsDir() {
 
-	fInfo, _ := os.Stat("/etc")
+	fInfo, _ := os.Stat(tips.Etc("/etc"))
 	fmt.Println(fInfo.IsDir()) // => "true"
 }
 
@@ -229,10 +272,11 @@ func dir_IsDir() {
 [ワイルドカードにマッチしたファイル全てに処理を行う](#dir_Glob)と同様に、
 Walk()で可能です。
 */
+// import "os"
 // import "path/filepath"
 
 func dir_ShowFullPath() {
-	filepath.Walk("/etc/", showFullPath)
+	filepath.Walk(tips.Etc("/etc/"), showFullPath)
 }
 
 func showFullPath(path string, info os.FileInfo, err error) error {
@@ -241,24 +285,52 @@ func showFullPath(path string, info os.FileInfo, err error) error {
 }
 
 //---------------------------------------------------
-// ディレクトリ
+// ディレクトリ内の全ファイル名をフルパスで表示 (Go 1.16以降)
 //---------------------------------------------------
-func Tips_file() {
-
-	dir_MakeDir()      // ディレクトリの作成
-	dir_RemoveDir()    // ディレクトリの削除
-	dir_RemoveDirAll() // 中身が空でないディレクトリを削除する
-	dir_Rename()       // ディレクトリ名を変更する
-	// ディレクトリの詳細情報を取得する
-	// ディレクトリのファイルモードを変更する
-	// ディレクトリの所有者とグループを変更する
-	// ディレクトリの最終アクセス時刻と最終更新日時を変更する
-	dir_Pwd()         // カレントディレクトリの取得と変更
-	dir_GetFileList() // ディレクトリ中のファイル一覧を取得する
-	dir_Glob()        // ワイルドカードにマッチしたファイル全てに処理を行う
-	dir_DirName()     // ファイル名からディレクトリ部分だけを切り出す
-	dir_IsDir()       // ディレクトリかどうか判定する
-	// ディレクトリ内の全ファイルに対して処理を行う
-	dir_ShowFullPath() // ディレクトリ内の全ファイル名をフルパスで表示
+/*
+WalkDir()を使うと、関数リテラルをその場で渡せて短く書けます。
+*/
+func dir_ShowFullPath_go1_16() {
+	filepath.WalkDir(tips.Etc("/etc/"), func(path string, d fs.DirEntry, err error) error {
+		fmt.Println(path)
+		return nil
+	})
+}
 
+//---------------------------------------------------
+// ディレクトリ
+//---------------------------------------------------
+//go:embed tips_dir.go
+var source []byte
+
+func init() {
+	tips.Register("pkg/tips_dir/tips_dir.go", "ディレクトリ", source, map[string]func(){
+		"dir_MakeDir":             dir_MakeDir,
+		"dir_RemoveDir":           dir_RemoveDir,
+		"dir_RemoveDirAll":        dir_RemoveDirAll,
+		"dir_Rename":              dir_Rename,
+		"dir_Pwd":                 dir_Pwd,
+		"dir_GetFileList":         dir_GetFileList,
+		"dir_GetFileList_go1_16":  dir_GetFileList_go1_16,
+		"dir_Glob":                dir_Glob,
+		"dir_Glob_go1_16":         dir_Glob_go1_16,
+		"dir_DirName":             dir_DirName,
+		"dir_IsDir":               dir_IsDir,
+		"dir_ShowFullPath":        dir_ShowFullPath,
+		"dir_ShowFullPath_go1_16": dir_ShowFullPath_go1_16,
+	})
+
+	// 逆引きRubyで対応するメソッド
+	tips.SetRuby(map[string][]string{
+		"dir_MakeDir":      []string{"Dir.mkdir", "FileUtils.mkdir_p"},
+		"dir_RemoveDir":    []string{"Dir.rmdir", "Dir.delete"},
+		"dir_RemoveDirAll": []string{"FileUtils.rm_r"},
+		"dir_Rename":       []string{"File.rename"},
+		"dir_Pwd":          []string{"Dir.pwd", "Dir.chdir"},
+		"dir_GetFileList":  []string{"Dir.entries", "Dir.foreach"},
+		"dir_Glob":         []string{"Dir.glob"},
+		"dir_DirName":      []string{"File.dirname"},
+		"dir_IsDir":        []string{"File.directory?"},
+		"dir_ShowFullPath": []string{"Find.find", "File.expand_path"},
+	})
 }
@@ -0,0 +1,144 @@
+// Code generated by make_examples; DO NOT EDIT.
+
+package tips_file
+
+import (
+	"time"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+	"github.com/ashitani/golangtips/pkg/tips/input"
+)
+
+// 次のTipsはExampleにできないので生成していません。
+//
+//   file_TempFile: 出力がない
+//   file_CopyFile: 出力がない
+//   file_FileType: /etc を使う ("/etc", "/etc/passwd", "/etc/password")
+//   file_Stat: /etc を使う ("/etc/passwd")
+//   file_ChOwn: 出力がない
+//   file_AbsPath: /etc を使う ("/etc", "/etc/passwd")
+
+func init() {
+	time.Local = time.FixedZone("JST", 9*60*60)
+}
+
+// ファイルをオープンする
+func Example_open() {
+	tips.RunInSandbox(file_Open)
+	// Output:
+	// Hello, golang!
+}
+
+// テキストファイルをオープンして内容を出力する
+func Example_read() {
+	tips.RunInSandbox(file_Read)
+	// Output:
+	// HELLO, GOLANG!
+}
+
+// 読み込む長さを指定する
+func Example_readLength() {
+	tips.RunInSandbox(file_ReadLength)
+	// Output:
+	// Read 10 bytes: HELLO, GOL
+}
+
+// ファイルの内容を一度に読み込む
+func Example_readAll() {
+	tips.RunInSandbox(file_ReadAll)
+	// Output:
+	// HELLO, GOLANG!
+}
+
+// 1行ずつ読み込みを行う
+func Example_readEachLine() {
+	tips.RunInSandbox(file_ReadEachLine)
+	// Output:
+	// Read 2 lines, 4 entries
+}
+
+// テキストファイルの特定の行を読み込む
+func Example_readSpecificLine() {
+	tips.RunInSandbox(file_ReadSpecificLine)
+	// Output:
+	// a,test
+}
+
+// 固定長レコードを読む
+func Example_formattedText() {
+	tips.RunInSandbox(file_FormattedText)
+	// Output:
+	// 従業員番号:	100002
+	// 氏名:		田中三郎太
+	// 部課コード:	1235
+	// 入社年度:	1980
+	// --------------------
+	// 従業員番号:	100003
+	// 氏名:		佐藤花子姫
+	// 部課コード:	1236
+	// 入社年度:	1990
+	// --------------------
+	// 従業員番号:	100001
+	// 氏名:		鈴木一郎太
+	// 部課コード:	1234
+	// 入社年度:	2001
+	// --------------------
+}
+
+// フィルタ系のコマンドを作成する
+func Example_filter() {
+	defer input.Feed(input.MustParse("line hoge\nline fuga\nline \"\"\n"))()
+	tips.RunInSandbox(file_Filter)
+	// Output:
+	// hoge
+	// fuga
+}
+
+// ファイルモードを変更する
+func Example_chMod() {
+	tips.RunInSandbox(file_ChMod)
+	// Output:
+	// -rw-------
+	// -rw-rw-rw-
+}
+
+// ファイルの最終アクセス時刻と最終更新日時を変更する
+func Example_changeTime() {
+	tips.RunInSandbox(file_ChangeTime)
+	// Output:
+	// 2001-05-22 23:59:59 +0900 JST
+	// 2001-05-01 00:00:00 +0900 JST
+}
+
+// ファイルパスからディレクトリパスを抜き出す
+func Example_dir() {
+	file_Dir()
+	// Output:
+	// /hoge
+	// /hoge/piyo
+	// .
+}
+
+// ファイルパスからファイル名を抜き出す
+func Example_basename() {
+	file_Basename()
+	// Output:
+	// piyo
+	// .c
+	// piyo
+}
+
+// パス名とファイル名を一度に抜き出す
+func Example_split() {
+	file_Split()
+	// Output:
+	// /hoge/
+	// piyo
+}
+
+// 拡張子を調べる
+func Example_ext() {
+	file_Ext()
+	// Output:
+	// .c
+}
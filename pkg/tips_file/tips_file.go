@@ -6,7 +6,9 @@ package tips_file
 
 import (
 	"bufio"
+	"embed"
 	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -16,6 +18,8 @@ import (
 	"strings"
 	"syscall"
 	"time"
+
+	"github.com/ashitani/golangtips/pkg/tips"
 )
 
 //---------------------------------------------------
@@ -148,7 +152,7 @@ func file_ReadEachLine() {
 func readAll(filename string) (string, error) {
 	fp, err := os.Open(filename)
 	if err != nil {
-		return "", fmt.Errorf(filename + " can't be opened")
+		return "", fmt.Errorf("%s can't be opened", filename)
 	}
 
 	ans := ""
@@ -175,7 +179,7 @@ func readAll(filename string) (string, error) {
 rubyのreadlinesのように、配列に読み込むような標準関数はありません。
 改行でSplitすれば代替になりますが、でかいファイルの場合は気をつけないとですね。
 */
-// import "ioutil"
+// import "io/ioutil"
 // import "strings"
 
 func file_ReadSpecificLine() {
@@ -190,7 +194,7 @@ func readLines(filename string) ([]string, error) {
 	ans := make([]string, 10)
 	data, err := ioutil.ReadFile(filename)
 	if err != nil {
-		return ans, fmt.Errorf(filename + " can't be opened")
+		return ans, fmt.Errorf("%s can't be opened", filename)
 	}
 	ans = strings.Split(string(data), "\n")
 
@@ -209,8 +213,9 @@ osごとのデフォルトのディレクトリが使われるようです。pre
 下記の例は、test.txtの内容を、TempFileを利用して大文字に変換します。
 */
 // import "bufio"
-// import "ioutil"
+// import "io/ioutil"
 // import "os"
+// import "strings"
 
 func file_TempFile() {
 
@@ -263,6 +268,7 @@ record という構造体を作り、その配列recordsに対して、
 // import "sort"
 // import "strconv"
 // import "os"
+// import "strings"
 
 func file_FormattedText() {
 	var rs records
@@ -401,11 +407,11 @@ os.Stat()でos.FileInfoが返ります。
 // import "os"
 
 func file_FileType() {
-	fmt.Println(isDir("/etc/passwd")) // => false
-	fmt.Println(isDir("/etc"))        // => true
+	fmt.Println(isDir(tips.Etc("/etc/passwd"))) // => false
+	fmt.Println(isDir(tips.Etc("/etc")))        // => true
 
-	fmt.Println(isExist("/etc/passwd"))   // => true
-	fmt.Println(isExist("/etc/password")) // => false
+	fmt.Println(isExist(tips.Etc("/etc/passwd")))   // => true
+	fmt.Println(isExist(tips.Etc("/etc/password"))) // => false
 }
 
 func isDir(filename string) bool {
@@ -427,13 +433,14 @@ func isExist(filename string) bool {
 //---------------------------------------------------
 /*
 むかしはFileStatでi-node番号とかもろもろ取得できたようですが、
-少なくともGo1.4ではsyscallにいます。Atimespec->AtimになったのはGo1.5から？
+少なくともGo1.4ではsyscallにいます。
+時刻のフィールド名はOSによって違い、LinuxではAtim、macOSではAtimespecです。
 */
 // import "syscall"
 
 func file_Stat() {
 	var s syscall.Stat_t
-	syscall.Stat("/etc/passwd", &s)
+	syscall.Stat(tips.Etc("/etc/passwd"), &s)
 	fmt.Println(s.Dev)
 	fmt.Println(s.Ino)
 	fmt.Println(s.Mode)
@@ -442,10 +449,10 @@ func file_Stat() {
 	fmt.Println(s.Gid)
 	fmt.Println(s.Size)
 	fmt.Println(s.Blocks)
-	// fmt.Println(s.Atim.Unix()) // Go1.4ではエラー
-	// fmt.Println(s.Mtim.Unix()) // Go1.4ではエラー
-	fmt.Println(s.Atimespec.Unix())
-	fmt.Println(s.Mtimespec.Unix())
+	fmt.Println(s.Atim.Unix())
+	fmt.Println(s.Mtim.Unix())
+	// fmt.Println(s.Atimespec.Unix()) // macOSの場合
+	// fmt.Println(s.Mtimespec.Unix()) // macOSの場合
 }
 
 //---------------------------------------------------
@@ -500,6 +507,7 @@ func file_ChOwn() {
 最終アクセス日を2001-5-22 23:59:59(JST)、
 最終更新日を2001-5-1 00:00:00(JST)に変更するものです。
 */
+// import "os"
 // import "syscall"
 // import "time"
 
@@ -513,9 +521,9 @@ func file_ChangeTime() {
 	//　確認
 	var s syscall.Stat_t
 	syscall.Stat("test.txt", &s)
-	sec, nsec := s.Atimespec.Unix()   // Go1.5以降ではAtimespec -> Atim
+	sec, nsec := s.Atim.Unix()        // macOSではAtimespec
 	fmt.Println(time.Unix(sec, nsec)) // => "2001-05-22 23:59:59 +0900 JST"
-	sec, nsec = s.Mtimespec.Unix()    // Go1.5以降ではAtimespec -> Atim
+	sec, nsec = s.Mtim.Unix()         // macOSではMtimespec
 	fmt.Println(time.Unix(sec, nsec)) // => "2001-05-01 00:00:00 +0900 JST"
 
 }
@@ -615,25 +623,75 @@ func file_Ext() {
 //---------------------------------------------------
 // ファイル
 //---------------------------------------------------
-func Tips_file() {
-	file_Open()             // ファイルをオープンする
-	file_Read()             // テキストファイルをオープンして内容を出力する
-	file_ReadLength()       // 読み込む長さを指定する
-	file_ReadAll()          // ファイルの内容を一度に読み込む
-	file_ReadEachLine()     // 1行ずつ読み込みを行う
-	file_ReadSpecificLine() // テキストファイルの特定の行を読み込む
-	file_TempFile()         // 一時ファイルを作成する
-	file_FormattedText()    // 固定長レコードを読む
-	file_CopyFile()         // ファイルをコピーする
-	file_Filter()           // フィルタ系のコマンドを作成する
-	file_FileType()         // ファイルタイプを取得する
-	file_Stat()             // ファイルの詳細情報を取得する
-	file_ChMod()            // ファイルモードを変更する
-	file_ChOwn()            // ファイルの所有者とグループを変更する
-	file_ChangeTime()       // ファイルの最終アクセス時刻と最終更新日時を変更する
-	file_AbsPath()          // 相対パスから絶対パスを求める
-	file_Dir()              // ファイルパスからディレクトリパスを抜き出す
-	file_Basename()         // ファイルパスからファイル名を抜き出す
-	file_Split()            // パス名とファイル名を一度に抜き出す
-	file_Ext()              // 拡張子を調べる
+//go:embed tips_file.go
+var source []byte
+
+// サンドボックスに置くファイル
+//
+//go:embed test.txt foo.csv fmtTxt.txt
+var fixtures embed.FS
+
+// file_ChMod は test.txt が -rw------- のところから始める
+var fixtureModes = map[string]fs.FileMode{
+	"test.txt": 0600,
+}
+
+func init() {
+	tips.AddFixtures("pkg/tips_file/tips_file.go", fixtures, fixtureModes)
+	tips.Register("pkg/tips_file/tips_file.go", "ファイル", source, map[string]func(){
+		"file_Open":             file_Open,
+		"file_Read":             file_Read,
+		"file_ReadLength":       file_ReadLength,
+		"file_ReadAll":          file_ReadAll,
+		"file_ReadEachLine":     file_ReadEachLine,
+		"file_ReadSpecificLine": file_ReadSpecificLine,
+		"file_TempFile":         file_TempFile,
+		"file_FormattedText":    file_FormattedText,
+		"file_CopyFile":         file_CopyFile,
+		"file_Filter":           file_Filter,
+		"file_FileType":         file_FileType,
+		"file_Stat":             file_Stat,
+		"file_ChMod":            file_ChMod,
+		"file_ChOwn":            file_ChOwn,
+		"file_ChangeTime":       file_ChangeTime,
+		"file_AbsPath":          file_AbsPath,
+		"file_Dir":              file_Dir,
+		"file_Basename":         file_Basename,
+		"file_Split":            file_Split,
+		"file_Ext":              file_Ext,
+	})
+
+	// 逆引きRubyで対応するメソッド
+	tips.SetRuby(map[string][]string{
+		"file_Open":             []string{"File.open"},
+		"file_Read":             []string{"IO#gets", "IO#each_line"},
+		"file_ReadLength":       []string{"IO#read"},
+		"file_ReadAll":          []string{"IO.read", "IO.readlines"},
+		"file_ReadEachLine":     []string{"IO#each_line", "IO.foreach"},
+		"file_ReadSpecificLine": []string{"IO.readlines"},
+		"file_TempFile":         []string{"Tempfile.new"},
+		"file_FormattedText":    []string{"String#unpack"},
+		"file_CopyFile":         []string{"FileUtils.cp"},
+		"file_Filter":           []string{"ARGF"},
+		"file_FileType":         []string{"File.ftype"},
+		"file_Stat":             []string{"File.stat"},
+		"file_ChMod":            []string{"File.chmod"},
+		"file_ChOwn":            []string{"File.chown"},
+		"file_ChangeTime":       []string{"File.utime"},
+		"file_AbsPath":          []string{"File.expand_path"},
+		"file_Dir":              []string{"File.dirname"},
+		"file_Basename":         []string{"File.basename"},
+		"file_Split":            []string{"File.split"},
+		"file_Ext":              []string{"File.extname"},
+	})
+
+	// 端末なしで実行するときの入力
+	tips.SetInputs(map[string]string{
+		"file_Filter": "line hoge\nline fuga\nline\n",
+	})
+
+	// 中心になる補助関数のベンチマーク
+	tips.SetBenchmarks(map[string]func(){
+		"file_ReadSpecificLine": func() { readLines("foo.csv") },
+	})
 }
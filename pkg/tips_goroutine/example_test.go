@@ -0,0 +1,32 @@
+// Code generated by make_examples; DO NOT EDIT.
+
+package tips_goroutine
+
+import (
+	"time"
+
+	"github.com/ashitani/golangtips/pkg/tips/input"
+)
+
+// 次のTipsはExampleにできないので生成していません。
+//
+//   goroutine_Create: 時間待ちで実行順を決めている (time.Sleep)
+//   goroutine_Argument: 時間待ちで実行順を決めている (time.Sleep)
+//   goroutine_Kill: 時間待ちで実行順を決めている (time.Sleep)
+//   goroutine_Stop: 時間待ちで実行順を決めている (time.Sleep)
+//   goroutine_ListGoroutines: 時間待ちで実行順を決めている (time.Sleep)
+//   goroutine_Mutex: 出力がない
+
+func init() {
+	time.Local = time.FixedZone("JST", 9*60*60)
+}
+
+// goroutine間で通信する
+func Example_com() {
+	defer input.Feed(input.MustParse("line 4\nline 9\nline 2\nwait 100ms\nline -1\n"))()
+	goroutine_Com()
+	// Output:
+	// Square(4) = 2.000000
+	// Square(9) = 3.000000
+	// Square(2) = 1.414214
+}
@@ -0,0 +1,61 @@
+package tips_goroutine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+	"github.com/ashitani/golangtips/pkg/tips/input"
+)
+
+// キー入力を待つTipsは時間待ちがあるのでExampleにできない。
+// SetInputs() で登録したスクリプトを流し込み、キーで動きが変わったことを確かめる。
+
+// 登録したスクリプトを流し込んでTipsを実行し、出力と実行にかかった時間を返す
+func runScripted(t *testing.T, id string, f func()) (string, time.Duration) {
+	tip, ok := tips.Lookup(id)
+	if !ok {
+		t.Fatalf("%s is not registered", id)
+	}
+	if len(tip.Input) == 0 {
+		t.Fatalf("%s has no input script", id)
+	}
+	defer input.Feed(tip.Input)()
+	start := time.Now()
+	out, err := tips.Capture(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out, time.Since(start)
+}
+
+// "." を押すとgoroutineが止められ、50個の"."を書き終える前に Killed が表示されること
+func TestKillByKey(t *testing.T) {
+	out, _ := runScripted(t, "goroutine_Kill", goroutine_Kill)
+	ls := strings.Split(out, "\n")
+	if len(ls) != 4 || ls[0] != "Started goroutine. Push \".\" to kill me." || ls[2] != "Killed" || ls[3] != "" {
+		t.Fatalf("output:\n%s\nwant the greeting, dots and Killed", out)
+	}
+	if n := len(ls[1]); n == 0 || n >= 50 || strings.Trim(ls[1], ".") != "" {
+		t.Errorf("dots %q: want fewer than 50 before the key", ls[1])
+	}
+}
+
+// "." で止めてもう一度 "." で再開し、最後のキーで終わること。
+// 50個の"."を100msおきに書くのに5秒、止めている間の分だけ長くかかる
+func TestStopByKey(t *testing.T) {
+	if testing.Short() {
+		t.Skip("takes more than 5 seconds")
+	}
+	out, d := runScripted(t, "goroutine_Stop", goroutine_Stop)
+	want := "Started goroutine. Push \".\" to stop/start me.\n" +
+		strings.Repeat(".", 50) + "\n" +
+		"Finished..push any key to abort.\n"
+	if out != want {
+		t.Errorf("output:\n%s\nwant:\n%s", out, want)
+	}
+	if min := 5300 * time.Millisecond; d < min {
+		t.Errorf("took %v, want at least %v; the goroutine was not stopped", d, min)
+	}
+}
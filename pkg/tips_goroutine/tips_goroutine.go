@@ -2,11 +2,12 @@
 goroutine
 */
 
-package tips_dir
+package tips_goroutine
 
 import (
+	_ "embed"
 	"fmt"
-	"github.com/tlorens/go-ibgetkey"
+	keyboard "github.com/tlorens/go-ibgetkey"
 	"io/ioutil"
 	"math"
 	"os"
@@ -14,6 +15,8 @@ import (
 	"strconv"
 	"sync"
 	"time"
+
+	"github.com/ashitani/golangtips/pkg/tips"
 )
 
 //---------------------------------------------------
@@ -111,7 +114,7 @@ func appleGoroutine(fruit string, a int, quit chan bool) {
 [go-ibgetkey](https://github.com/tlorens/go-ibgetkey)という
 ライブラリを使いました。
 */
-// import "github.com/tlorens/go-ibgetkey"
+// import keyboard "github.com/tlorens/go-ibgetkey"
 // import "time"
 
 func goroutine_Kill() {
@@ -164,7 +167,7 @@ func killableGoroutine(kill, finished chan bool) {
 下記のプログラムはGorutine内で"."を100msec置きに50回表示して終了しますが、
 "."を入力するとGorutineを途中で停止・再開します。
 */
-// import "github.com/tlorens/go-ibgetkey"
+// import keyboard "github.com/tlorens/go-ibgetkey"
 // import "time"
 
 func goroutine_Stop() {
@@ -303,6 +306,8 @@ syncパッケージにMutexがあります。複数のgoroutine終了を待つ
 */
 //import "sync"
 //import "io/ioutil"
+//import "os"
+//import "strconv"
 
 func goroutine_Mutex() {
 	wg := new(sync.WaitGroup)
@@ -338,14 +343,35 @@ func read() int {
 //---------------------------------------------------
 // goroutine
 //---------------------------------------------------
-func Tips_goroutine() {
-	goroutine_Create()   // goroutineを生成する
-	goroutine_Argument() // goroutineに引数を渡す
-	// goroutineの終了を待つ
-	goroutine_Kill()           // goroutineの実行を終了させる
-	goroutine_Stop()           // goroutineを停止する
-	goroutine_ListGoroutines() // 実行中のgoroutine一覧を取得する
-	goroutine_Com()            // goroutine間で通信する
-	goroutine_Mutex()          // goroutine間の競合を回避する(Mutex)
-
+//go:embed tips_goroutine.go
+var source []byte
+
+func init() {
+	tips.Register("pkg/tips_goroutine/tips_goroutine.go", "goroutine", source, map[string]func(){
+		"goroutine_Create":         goroutine_Create,
+		"goroutine_Argument":       goroutine_Argument,
+		"goroutine_Kill":           goroutine_Kill,
+		"goroutine_Stop":           goroutine_Stop,
+		"goroutine_ListGoroutines": goroutine_ListGoroutines,
+		"goroutine_Com":            goroutine_Com,
+		"goroutine_Mutex":          goroutine_Mutex,
+	})
+
+	// 端末なしで実行するときの入力
+	tips.SetInputs(map[string]string{
+		"goroutine_Kill": "wait 350ms\nkey .\n",
+		"goroutine_Stop": "wait 350ms\nkey .\nwait 500ms\nkey .\nwait 5s\nkey q\n",
+		"goroutine_Com":  "line 4\nline 9\nline 2\nwait 100ms\nline -1\n",
+	})
+
+	// 逆引きRubyで対応するメソッド
+	tips.SetRuby(map[string][]string{
+		"goroutine_Create":         []string{"Thread.new"},
+		"goroutine_Argument":       []string{"Thread.new"},
+		"goroutine_Kill":           []string{"Thread#kill", "Thread#exit"},
+		"goroutine_Stop":           []string{"Thread.stop", "Thread#wakeup", "Thread#run"},
+		"goroutine_ListGoroutines": []string{"Thread.list"},
+		"goroutine_Com":            []string{"Queue#push", "Queue#pop"},
+		"goroutine_Mutex":          []string{"Mutex#synchronize"},
+	})
 }
@@ -0,0 +1,113 @@
+// Code generated by make_examples; DO NOT EDIT.
+
+package tips_map
+
+import "time"
+
+// 次のTipsはExampleにできないので生成していません。
+//
+//   map_Random: 現在時刻を使う (time.Now), 乱数を使う (rand.Intn, rand.Seed)
+//   map_Random_go1_20: 乱数を使う (rand.Intn)
+
+func init() {
+	time.Local = time.FixedZone("JST", 9*60*60)
+}
+
+// プログラム中でマップを定義する
+func Example_map() {
+	map_Map()
+	// Output:
+	// map[apple:150 banana:300 lemon:300]
+}
+
+// キーに関連付けられた値を取得する
+func Example_get() {
+	map_Get()
+	// Output:
+	// 150
+	// 300
+	// 300
+	// 0
+	// 150
+	// true
+	// 0
+	// false
+}
+
+// マップに要素を追加する
+func Example_add() {
+	map_Add()
+	// Output:
+	// 150
+}
+
+// マップ内にキーが存在するかどうか調べる
+func Example_hasKey() {
+	map_HasKey()
+	// Output:
+	// true
+	// false
+}
+
+// マップの要素数を取得する
+func Example_length() {
+	map_Length()
+	// Output:
+	// 3
+}
+
+// キーが存在しない場合のデフォルト値を設定する
+func Example_default() {
+	map_Default()
+	// Output:
+	// 150
+	// 100
+}
+
+// マップからエントリを削除する
+func Example_delete() {
+	map_Delete()
+	// Output:
+	// banana not found
+	// map[apple:150]
+	// map[banana:300 lemon:400]
+}
+
+// マップの全エントリに対してブロックを実行する
+func Example_block() {
+	map_Block()
+	// Output:
+	// [apple banana lemon]
+	// 750
+}
+
+// マップを配列に変換する
+func Example_toArray() {
+	map_ToArray()
+	// Output:
+	// [apple banana lemon]
+	// [150 300 300]
+	// [[apple 150] [banana 300] [lemon 300]]
+	// [150 300]
+}
+
+// マップを空にする
+func Example_clear() {
+	map_Clear()
+	// Output:
+	// map[]
+}
+
+// マップを値で降順、値が等しい場合キーで昇順にソートする
+func Example_sort() {
+	map_Sort()
+	// Output:
+	// [{ada 1} {basha 3} {poeni 3} {hoge 4}]
+}
+
+// 複数のマップをマージする
+func Example_merge() {
+	map_Merge()
+	// Output:
+	// map[key1:val1 key2:val2 key3:val3]
+}
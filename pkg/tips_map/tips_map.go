@@ -5,10 +5,11 @@
 package tips_map
 
 import (
+	_ "embed"
 	"fmt"
-	"math/rand"
 	"sort"
-	"time"
+
+	"github.com/ashitani/golangtips/pkg/tips"
 )
 
 //---------------------------------------------------
@@ -108,7 +109,7 @@ func map_Default() {
 	m := map[string]int{"apple": 150, "banana": 300, "lemon": 300}
 	dm := dmap{m}
 
-	fmt.Println(dm.Get("apple"))  // => "300"
+	fmt.Println(dm.Get("apple"))  // => "150"
 	fmt.Println(dm.Get("papaia")) // => "100"
 }
 
@@ -195,7 +196,9 @@ for key, value := range m {
 }
 ```
 でkey,valueを取り出しつつエントリを走査できます。
+rangeで回す順番は実行のたびに変わるので、順番が大事なときは並べ替えます。
 */
+//import "sort"
 
 func map_Block() {
 	m := map[string]int{"apple": 150, "banana": 300, "lemon": 300}
@@ -206,6 +209,7 @@ func map_Block() {
 		fruits = append(fruits, k)
 		sum += v
 	}
+	sort.Strings(fruits)
 	fmt.Println(fruits) // => "[apple banana lemon]"
 	fmt.Println(sum)    // => "750"
 }
@@ -216,12 +220,14 @@ func map_Block() {
 /*
 rubyのkeys(), values(), to_a(), indexes() はすべて
 range()で実装できます。
+rangeで回す順番は実行のたびに変わるので、キーを並べ替えてから取り出しています。
 */
+//import "sort"
 func map_ToArray() {
 	m := map[string]int{"apple": 150, "banana": 300, "lemon": 300}
 	fmt.Println(keys(m))   // => "[apple banana lemon]"
 	fmt.Println(values(m)) // => "[150 300 300]"
-	fmt.Println(to_a(m))   // => "[[lemon 300] [apple 150] [banana 300]]"
+	fmt.Println(to_a(m))   // => "[[apple 150] [banana 300] [lemon 300]]"
 
 	keys := []string{"apple", "lemon"}
 	fmt.Println(indexes(m, keys)) // => "[150 300]"
@@ -233,21 +239,22 @@ func keys(m map[string]int) []string {
 	for k, _ := range m {
 		ks = append(ks, k)
 	}
+	sort.Strings(ks)
 	return ks
 }
 
 func values(m map[string]int) []int {
 	vs := []int{}
-	for _, v := range m {
-		vs = append(vs, v)
+	for _, k := range keys(m) {
+		vs = append(vs, m[k])
 	}
 	return vs
 }
 
 func to_a(m map[string]int) []interface{} {
 	a := []interface{}{}
-	for k, v := range m {
-		a = append(a, []interface{}{k, v})
+	for _, k := range keys(m) {
+		a = append(a, []interface{}{k, m[k]})
 	}
 	return a
 }
@@ -324,47 +331,75 @@ func (l List) Less(i, j int) bool {
 
 乱数は[擬似乱数を生成する](http://ashitani.jp/golangtips/tips_num.html#num_Rand)
 にあります。
+
+rangeで回す順番も実行のたびに変わるので、キーを並べ替えてから選びます。
+こうしておくと、シードを決めれば毎回同じキーが選ばれます。
 */
 // import "math/rand"
+// import "sort"
 // import "time"
 func map_Random() {
 	m := map[string]int{"apple": 150, "banana": 300, "lemon": 300}
 
-	rand.Seed(time.Now().UnixNano()) //Seed
+	tips.Rand.Seed(tips.Clock.Now().UnixNano()) //Seed
 
-	fmt.Println(choice(m))
-	fmt.Println(choice(m))
-	fmt.Println(choice(m))
-	fmt.Println(choice(m))
+	fmt.Println(choice_key(m))
+	fmt.Println(choice_key(m))
+	fmt.Println(choice_key(m))
+	fmt.Println(choice_key(m))
 
 }
 
-func choice(m map[string]int) string {
-	l := len(m)
-	i := 0
+func choice_key(m map[string]int) string {
+	ks := []string{}
+	for k, _ := range m {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
 
-	index := rand.Intn(l)
+	index := tips.Rand.Intn(len(ks))
+	return ks[index]
+}
 
-	ans := ""
-	for k, _ := range m {
-		if index == i {
-			ans = k
-			break
-		} else {
-			i++
-		}
+//---------------------------------------------------
+//マップの要素をランダムに抽出する (Go 1.20以降)
+//---------------------------------------------------
+/*
+Go 1.20からはrand.Seed()を呼ばなくても、実行のたびに違う乱数になります。
+何度も選ぶなら、並べ替えたキーのスライスを一度だけ作っておけば、選ぶたびにマップ全体をなめずに済みます。
+*/
+func map_Random_go1_20() {
+	m := map[string]int{"apple": 150, "banana": 300, "lemon": 300}
+
+	ks := sorted_keys(m)
+	fmt.Println(ks[tips.Rand.Intn(len(ks))])
+	fmt.Println(ks[tips.Rand.Intn(len(ks))])
+	fmt.Println(ks[tips.Rand.Intn(len(ks))])
+	fmt.Println(ks[tips.Rand.Intn(len(ks))])
+}
+
+func sorted_keys(m map[string]int) []string {
+	ks := make([]string, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
 	}
-	return ans
+	sort.Strings(ks)
+	return ks
 }
 
 //---------------------------------------------------
 //複数のマップをマージする
 //---------------------------------------------------
+/*
+組み込みのマージはないので、新しいマップに両方をコピーします。
+キーが重なった場合は後のマップの値になります。
+Go 1.21からは[maps.Copy()](https://pkg.go.dev/maps#Copy)も使えます。
+*/
 func map_Merge() {
 	m1 := map[string]string{"key1": "val1", "key2": "val2"}
 	m2 := map[string]string{"key3": "val3"}
 
-	fmt.Println(merge(m1, m2)) // => "map[val1:key1 val2:key2 val3:key3]"
+	fmt.Println(merge(m1, m2)) // => "map[key1:val1 key2:val2 key3:val3]"
 }
 
 func merge(m1, m2 map[string]string) map[string]string {
@@ -382,18 +417,49 @@ func merge(m1, m2 map[string]string) map[string]string {
 //---------------------------------------------------
 // マップ(ハッシュ)
 //---------------------------------------------------
-func Tips_map() {
-	map_Map()     // プログラム中でマップを定義する
-	map_Get()     // キーに関連付けられた値を取得する
-	map_Add()     // マップに要素を追加する
-	map_HasKey()  // マップ内にキーが存在するかどうか調べる
-	map_Length()  // マップの要素数を取得する
-	map_Default() // キーが存在しない場合のデフォルト値を設定する
-	map_Delete()  // マップからエントリを削除する
-	map_Block()   // マップの全エントリに対してブロックを実行する
-	map_ToArray() // マップを配列に変換する
-	map_Clear()   // マップを空にする
-	map_Sort()    // マップを値で降順、値が等しい場合キーで昇順にソートする
-	map_Random()  // マップの要素をランダムに抽出する
-	map_Merge()   // 複数のマップをマージする
+//go:embed tips_map.go
+var source []byte
+
+func init() {
+	tips.Register("pkg/tips_map/tips_map.go", "マップ", source, map[string]func(){
+		"map_Map":           map_Map,
+		"map_Get":           map_Get,
+		"map_Add":           map_Add,
+		"map_HasKey":        map_HasKey,
+		"map_Length":        map_Length,
+		"map_Default":       map_Default,
+		"map_Delete":        map_Delete,
+		"map_Block":         map_Block,
+		"map_ToArray":       map_ToArray,
+		"map_Clear":         map_Clear,
+		"map_Sort":          map_Sort,
+		"map_Random":        map_Random,
+		"map_Random_go1_20": map_Random_go1_20,
+		"map_Merge":         map_Merge,
+	})
+
+	// 逆引きRubyで対応するメソッド
+	tips.SetRuby(map[string][]string{
+		"map_Map":     []string{"Hash.[]"},
+		"map_Get":     []string{"Hash#[]", "Hash#fetch"},
+		"map_Add":     []string{"Hash#[]=", "Hash#store"},
+		"map_HasKey":  []string{"Hash#key?", "Hash#has_key?", "Hash#include?"},
+		"map_Length":  []string{"Hash#length", "Hash#size"},
+		"map_Default": []string{"Hash.new", "Hash#default="},
+		"map_Delete":  []string{"Hash#delete"},
+		"map_Block":   []string{"Hash#each", "Hash#each_pair"},
+		"map_ToArray": []string{"Hash#to_a"},
+		"map_Clear":   []string{"Hash#clear"},
+		"map_Sort":    []string{"Hash#sort", "Hash#sort_by"},
+		"map_Random":  []string{"Hash#keys", "Array#sample"},
+		"map_Merge":   []string{"Hash#merge", "Hash#update"},
+	})
+
+	// 中心になる補助関数のベンチマーク
+	m := map[string]int{"apple": 150, "banana": 300, "lemon": 300}
+	ks := sorted_keys(m)
+	tips.SetBenchmarks(map[string]func(){
+		"map_Random":        func() { choice_key(m) },
+		"map_Random_go1_20": func() { _ = ks[tips.Rand.Intn(len(ks))] },
+	})
 }
@@ -0,0 +1,108 @@
+// Code generated by make_examples; DO NOT EDIT.
+
+package tips_num
+
+import "time"
+
+// 次のTipsはExampleにできないので生成していません。
+//
+//   num_Rand: 現在時刻を使う (time.Now), 乱数を使う (rand.Float32, rand.Intn, rand.Seed)
+//   num_Rand_go1_20: 乱数を使う (rand.Float32, rand.Intn)
+
+func init() {
+	time.Local = time.FixedZone("JST", 9*60*60)
+}
+
+// 2進数・8進数・16進数で数値を扱うには
+func Example_base() {
+	num_Base()
+	// Output:
+	// 16
+	// 16
+	// 16
+}
+
+// 数値を2進数・8進数・16進数表現の文字列に変換するには
+func Example_format() {
+	num_Format()
+	// Output:
+	// 11111111
+	// 377
+	// ff
+}
+
+// 任意のビット位置の値を参照する
+func Example_refBit() {
+	num_RefBit()
+	// Output:
+	// 0
+	// 1
+}
+
+// 除算の商と余りを求める
+func Example_mod() {
+	num_Mod()
+	// Output:
+	// 3 1
+}
+
+// 絶対値を求める
+func Example_abs() {
+	num_Abs()
+	// Output:
+	// 5
+	// 100
+}
+
+// 小数を切り上げ・切り捨て・四捨五入するには
+func Example_ceilFloor() {
+	num_CeilFloor()
+	// Output:
+	// 4
+	// 3
+	// 3
+	// 4
+}
+
+// 小数を切り上げ・切り捨て・四捨五入するには (Go 1.10以降)
+func Example_ceilFloor_go110() {
+	num_CeilFloor_go1_10()
+	// Output:
+	// 4
+	// 3
+	// 3
+	// 4
+}
+
+// 三角関数を計算する
+func Example_sinCos() {
+	num_SinCos()
+	// Output:
+	// 1
+	// 1
+	// 0
+}
+
+// 対数を計算する
+func Example_log() {
+	num_Log()
+	// Output:
+	// 1
+	// 1
+}
+
+// 平方根を求める
+func Example_sqrt() {
+	num_Sqrt()
+	// Output:
+	// 10
+	// 3.1622776601683795
+}
+
+// 整数と浮動小数を相互変換する（精度の変換）
+func Example_conv() {
+	num_Conv()
+	// Output:
+	// 1, 1.000000
+	// 3, 3.000000
+}
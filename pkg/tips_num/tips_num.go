@@ -5,11 +5,12 @@
 package tips_num
 
 import (
+	_ "embed"
 	"fmt"
 	"math"
-	"math/rand"
 	"strconv"
-	"time"
+
+	"github.com/ashitani/golangtips/pkg/tips"
 )
 
 //---------------------------------------------------
@@ -28,12 +29,15 @@ func num_Base() {
 //---------------------------------------------------
 // 数値を2進数・8進数・16進数表現の文字列に変換するには
 //---------------------------------------------------
+/*
+fmt.Sprintf()の%b, %o, %xを使います。逆の変換は[8進文字列を整数に変換する](#string_ParseOct)のstrconv.ParseInt()です。
+*/
 func num_Format() {
 	s := ""
 	s = fmt.Sprintf("%b", 255)
 	fmt.Println(s) // => "11111111"
 	s = fmt.Sprintf("%o", 255)
-	fmt.Println(s) // => "11111111"
+	fmt.Println(s) // => "377"
 	s = fmt.Sprintf("%x", 255)
 	fmt.Println(s) // => "ff"
 }
@@ -41,6 +45,9 @@ func num_Format() {
 //---------------------------------------------------
 //任意のビット位置の値を参照する
 //---------------------------------------------------
+/*
+シフトして1とANDを取ります。Rubyの`i[4]`のような書き方はありません。
+*/
 func num_RefBit() {
 	i := 0x10
 	fmt.Println(refbit(i, 0)) // => "0"
@@ -55,16 +62,22 @@ func refbit(i int, b uint) int {
 //---------------------------------------------------
 // 除算の商と余りを求める
 //---------------------------------------------------
+/*
+整数同士の/は商、%は余りです。負の数の余りはRubyと違って被除数と同じ符号になります。
+*/
 func num_Mod() {
 	i := 10
 	d := i / 3
 	m := i % 3
-	fmt.Println(d, m) // => 3,1
+	fmt.Println(d, m) // => "3 1"
 }
 
 //---------------------------------------------------
 // 絶対値を求める
 //---------------------------------------------------
+/*
+math.Abs()はfloat64しか受け取らないので、整数は変換して渡します。
+*/
 func num_Abs() {
 	// import "math"
 	i := -5
@@ -77,6 +90,10 @@ func num_Abs() {
 //---------------------------------------------------
 // 小数を切り上げ・切り捨て・四捨五入するには
 //---------------------------------------------------
+/*
+切り上げはmath.Ceil()、切り捨てはmath.Trunc()です。
+四捨五入は0.5を足して切り捨てる関数を書いています。Go 1.10からはmath.Round()があります。
+*/
 // import "math"
 
 func num_CeilFloor() {
@@ -93,9 +110,28 @@ func round(f float64) float64 {
 	return math.Floor(f + .5)
 }
 
+//---------------------------------------------------
+// 小数を切り上げ・切り捨て・四捨五入するには (Go 1.10以降)
+//---------------------------------------------------
+/*
+Go 1.10でmath.Round()が入りました。0.5は0から遠い方に丸めます。
+偶数に丸めたいときはmath.RoundToEven()です。
+*/
+func num_CeilFloor_go1_10() {
+	f := 3.4
+	fmt.Println(math.Ceil(f))  // =>"4"
+	fmt.Println(math.Trunc(f)) // =>"3"
+	fmt.Println(math.Round(f)) // =>"3"
+	f = 3.5
+	fmt.Println(math.Round(f)) // =>"4"
+}
+
 //---------------------------------------------------
 // 三角関数を計算する
 //---------------------------------------------------
+/*
+mathパッケージの関数を使います。角度はラジアンで渡します。
+*/
 func num_SinCos() {
 	fmt.Println(math.Sin(math.Pi / 2)) // => "1"
 	fmt.Println(math.Cos(0))           // => "1"
@@ -105,6 +141,9 @@ func num_SinCos() {
 //---------------------------------------------------
 // 対数を計算する
 //---------------------------------------------------
+/*
+自然対数はmath.Log()、常用対数はmath.Log10()です。
+*/
 func num_Log() {
 	fmt.Println(math.Log(math.E)) // => "1"
 	fmt.Println(math.Log10(10))   // => "1"
@@ -113,6 +152,9 @@ func num_Log() {
 //---------------------------------------------------
 // 平方根を求める
 //---------------------------------------------------
+/*
+math.Sqrt()を使います。
+*/
 func num_Sqrt() {
 	fmt.Println(math.Sqrt(100)) // => "10"
 	fmt.Println(math.Sqrt(10))  // => "3.1622776601683795"
@@ -131,15 +173,30 @@ Intn(100)は[0,100)の乱数を返します。
 
 func num_Rand() {
 
-	rand.Seed(time.Now().UnixNano()) //Seed
+	tips.Rand.Seed(tips.Clock.Now().UnixNano()) //Seed
 
-	fmt.Println(rand.Float32())
-	fmt.Println(rand.Intn(100))
+	fmt.Println(tips.Rand.Float32())
+	fmt.Println(tips.Rand.Intn(100))
+}
+
+//---------------------------------------------------
+// 擬似乱数を生成する (Go 1.20以降)
+//---------------------------------------------------
+/*
+Go 1.20からは、Seed()を呼ばなくても実行のたびに違う乱数になります。rand.Seed()は非推奨です。
+同じ系列を再現したいときは、rand.New(rand.NewSource(1))のように生成器を作ります。
+*/
+func num_Rand_go1_20() {
+	fmt.Println(tips.Rand.Float32())
+	fmt.Println(tips.Rand.Intn(100))
 }
 
 //---------------------------------------------------
 // 整数と浮動小数を相互変換する（精度の変換）
 //---------------------------------------------------
+/*
+float64(i)、int(f)のように型変換します。int()は小数点以下を切り捨てます。
+*/
 func num_Conv() {
 	i := 1
 	f := float64(i)
@@ -153,17 +210,38 @@ func num_Conv() {
 //---------------------------------------------------
 // 数値
 //---------------------------------------------------
-func Tips_num() {
-	num_Base()      // 2進数・8進数・16進数で数値を扱うには
-	num_Format()    // 数値を2進数・8進数・16進数表現の文字列に変換するには
-	num_RefBit()    // 任意のビット位置の値を参照する
-	num_Mod()       // 除算の商と余りを求める
-	num_Abs()       // 絶対値を求める
-	num_CeilFloor() // 小数を切り上げ・切り捨て・四捨五入するには
-	num_SinCos()    // 三角関数を計算する
-	num_Log()       // 対数を計算する
-	num_Sqrt()      // 平方根を求める
-	num_Rand()      // 擬似乱数を生成する
-	num_Conv()      // 整数と浮動小数を相互変換する（精度の変換）
-
+//go:embed tips_num.go
+var source []byte
+
+func init() {
+	tips.Register("pkg/tips_num/tips_num.go", "数値", source, map[string]func(){
+		"num_Base":             num_Base,
+		"num_Format":           num_Format,
+		"num_RefBit":           num_RefBit,
+		"num_Mod":              num_Mod,
+		"num_Abs":              num_Abs,
+		"num_CeilFloor":        num_CeilFloor,
+		"num_CeilFloor_go1_10": num_CeilFloor_go1_10,
+		"num_SinCos":           num_SinCos,
+		"num_Log":              num_Log,
+		"num_Sqrt":             num_Sqrt,
+		"num_Rand":             num_Rand,
+		"num_Rand_go1_20":      num_Rand_go1_20,
+		"num_Conv":             num_Conv,
+	})
+
+	// 逆引きRubyで対応するメソッド
+	tips.SetRuby(map[string][]string{
+		"num_Base":      []string{"String#to_i", "Kernel#Integer"},
+		"num_Format":    []string{"Integer#to_s", "Kernel#sprintf"},
+		"num_RefBit":    []string{"Integer#[]"},
+		"num_Mod":       []string{"Integer#divmod", "Integer#/", "Integer#%"},
+		"num_Abs":       []string{"Integer#abs"},
+		"num_CeilFloor": []string{"Float#ceil", "Float#floor", "Float#round"},
+		"num_SinCos":    []string{"Math.sin", "Math.cos", "Math.tan"},
+		"num_Log":       []string{"Math.log", "Math.log10"},
+		"num_Sqrt":      []string{"Math.sqrt"},
+		"num_Rand":      []string{"Kernel#rand", "Kernel#srand"},
+		"num_Conv":      []string{"Integer#to_f", "Float#to_i"},
+	})
 }
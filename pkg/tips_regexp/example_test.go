@@ -0,0 +1,118 @@
+// Code generated by make_examples; DO NOT EDIT.
+
+package tips_regexp
+
+import "time"
+
+func init() {
+	time.Local = time.FixedZone("JST", 9*60*60)
+}
+
+// 正規表現を使う
+func Example_regexp() {
+	regexp_Regexp()
+	// Output:
+	// golang
+}
+
+// 文字にマッチさせる
+func Example_match() {
+	regexp_Match()
+	// Output:
+	// false
+	// true
+}
+
+// 繰り返し文字とマッチさせる
+func Example_repeat() {
+	regexp_Repeat()
+	// Output:
+	// true
+	// true
+	// true
+	// true
+	// true
+	// false
+	// false
+	// true
+	// true
+	// false
+	// false
+	// false
+	// true
+	// true
+	// true
+	// true
+	// true
+	// false
+}
+
+// 数字だけ・アルファベットだけとマッチさせる
+func Example_numAlpha() {
+	regexp_NumAlpha()
+	// Output:
+	// true
+	// true
+	// false
+	// true
+	// false
+	// true
+	// false
+	// false
+	// true
+	// false
+}
+
+// 改行コードを含む文字列にマッチさせる
+func Example_multiLine() {
+	regexp_MultiLine()
+	// Output:
+	// true
+}
+
+// 正規表現を使って文字列を置き換える
+func Example_replace() {
+	regexp_Replace()
+	// Output:
+	// Copyleft 2015 by ASHITANI Tatsuji.
+}
+
+// n番めのマッチを見つける
+func Example_numbering() {
+	regexp_Numbering()
+	// Output:
+	// 1(2)3(4)5(6)
+}
+
+// パターンで区切られたレコードを読む
+func Example_split() {
+	regexp_Split()
+	// Output:
+	// 001
+	// ASHITANI Tatsuji
+	// Yokohama
+}
+
+// マッチした文字列を全て抜き出して配列へ格納する
+func Example_findAll() {
+	regexp_FindAll()
+	// Output:
+	// [[0045-111-2222] [0045-222-2222]]
+	// [hoge:0045-111-2222 hoge 0045-111-2222]
+	// [boke:0045-222-2222 boke 0045-222-2222]
+}
+
+// 正規表現にコメントを付ける
+func Example_comment() {
+	regexp_Comment()
+	// Output:
+	// [hoge:0045-111-2222 hoge 0045-111-2222]
+	// [boke:0045-222-2222 boke 0045-222-2222]
+}
+
+// 正規表現内でString型変数を使う
+func Example_string() {
+	regexp_String()
+	// Output:
+	// [[a]]
+}
@@ -5,8 +5,11 @@
 package tips_regexp
 
 import (
+	_ "embed"
 	"fmt"
 	"regexp"
+
+	"github.com/ashitani/golangtips/pkg/tips"
 )
 
 //---------------------------------------------------
@@ -91,6 +94,9 @@ func check_regexp(reg, str string) {
 //---------------------------------------------------
 // 数字だけ・アルファベットだけとマッチさせる
 //---------------------------------------------------
+/*
+Rubyと同じく`[0-9]`や`[A-Z]`のような文字クラスを使います。
+*/
 // import "regexp"
 
 func regexp_NumAlpha() {
@@ -108,10 +114,6 @@ func regexp_NumAlpha() {
 	check_regexp(`[^0-9]`, "5") // => "false"
 }
 
-//func check_regexp(reg, str string) {
-//	fmt.Println(regexp.MustCompile(reg).Match([]byte(str)))
-//}
-
 //---------------------------------------------------
 // 改行コードを含む文字列にマッチさせる
 //---------------------------------------------------
@@ -126,10 +128,6 @@ func regexp_MultiLine() {
 	check_regexp(`(?m)^(w.*)$`, txt)
 }
 
-//func check_regexp(reg, str string) {
-//	fmt.Println(regexp.MustCompile(reg).Match([]byte(str)))
-//}
-
 //---------------------------------------------------
 // 正規表現を使って文字列を置き換える
 //---------------------------------------------------
@@ -248,17 +246,36 @@ func regexp_String() {
 //---------------------------------------------------
 // 正規表現（パターンマッチ）
 //---------------------------------------------------
-func Tips_regexp() {
-	regexp_Regexp()    // 正規表現を使う
-	regexp_Match()     // 文字にマッチさせる
-	regexp_Repeat()    // 繰り返し文字とマッチさせる
-	regexp_NumAlpha()  // 数字だけ・アルファベットだけとマッチさせる
-	regexp_MultiLine() // 改行コードを含む文字列にマッチさせる
-	regexp_Replace()   // 正規表現を使って文字列を置き換える
-	regexp_Numbering() // n番めのマッチを見つける
-	regexp_Split()     // パターンで区切られたレコードを読む
-	regexp_FindAll()   // マッチした文字列を全て抜き出して配列へ格納する
-	regexp_Comment()   // 正規表現にコメントを付ける
-	regexp_String()    // 正規表現内でString型変数を使う
-
+//go:embed tips_regexp.go
+var source []byte
+
+func init() {
+	tips.Register("pkg/tips_regexp/tips_regexp.go", "正規表現", source, map[string]func(){
+		"regexp_Regexp":    regexp_Regexp,
+		"regexp_Match":     regexp_Match,
+		"regexp_Repeat":    regexp_Repeat,
+		"regexp_NumAlpha":  regexp_NumAlpha,
+		"regexp_MultiLine": regexp_MultiLine,
+		"regexp_Replace":   regexp_Replace,
+		"regexp_Numbering": regexp_Numbering,
+		"regexp_Split":     regexp_Split,
+		"regexp_FindAll":   regexp_FindAll,
+		"regexp_Comment":   regexp_Comment,
+		"regexp_String":    regexp_String,
+	})
+
+	// 逆引きRubyで対応するメソッド
+	tips.SetRuby(map[string][]string{
+		"regexp_Regexp":    []string{"Regexp.new"},
+		"regexp_Match":     []string{"Regexp#match", "String#=~"},
+		"regexp_Repeat":    []string{"String#=~"},
+		"regexp_NumAlpha":  []string{"String#=~"},
+		"regexp_MultiLine": []string{"Regexp::MULTILINE"},
+		"regexp_Replace":   []string{"String#sub", "String#gsub"},
+		"regexp_Numbering": []string{"String#scan"},
+		"regexp_Split":     []string{"String#split"},
+		"regexp_FindAll":   []string{"String#scan"},
+		"regexp_Comment":   []string{"Regexp::EXTENDED"},
+		"regexp_String":    []string{"Regexp.new", "Regexp.escape"},
+	})
 }
@@ -0,0 +1,298 @@
+// Code generated by make_examples; DO NOT EDIT.
+
+package tips_slice
+
+import "time"
+
+// 次のTipsはExampleにできないので生成していません。
+//
+//   slice_Choice: 現在時刻を使う (time.Now), 乱数を使う (rand.Intn, rand.Seed)
+//   slice_Choice_go1_20: 乱数を使う (rand.Intn)
+
+func init() {
+	time.Local = time.FixedZone("JST", 9*60*60)
+}
+
+// プログラムで配列を定義する
+func Example_define() {
+	slice_Define()
+	// Output:
+	// [apple orange lemon]
+	// [55 49 100 150 0]
+	// [apple 10 2.5]
+	// 3
+	// 250
+	// lemon
+}
+
+// m x n 行列の形で配列の配列を初期化する
+func Example_sliceOfSlice() {
+	slice_SliceOfSlice()
+	// Output:
+	// [[0 7 0] [0 0 0] [0 0 0] [0 0 0]]
+	// {0, 7, 0,
+	//  0, 0, 0,
+	//  0, 0, 0,
+	//  0, 0, 0}
+}
+
+// 配列要素をカンマ区切りで出力する
+func Example_join() {
+	slice_Join()
+	// Output:
+	// apple,orange,lemon
+	// apple#orange#lemon
+	// 55,49,100,100,0
+	// 55,49,100,100,0
+	// 3,apple,250,orange,400
+}
+
+// 配列の要素数を取得する
+func Example_count() {
+	slice_Count()
+	// Output:
+	// 3
+	// 5
+	// 3
+}
+
+// 配列に要素を追加する
+func Example_append() {
+	slice_Append()
+	// Output:
+	// [1 2 3 4 5 99]
+	// [99 1 2 3 4 5 99]
+}
+
+// 配列の先頭または末尾から要素を取りだす
+func Example_pop() {
+	slice_Pop()
+	// Output:
+	// 10
+	// 5
+	// [1 2 3 4]
+}
+
+// 部分配列を取りだす
+func Example_slice() {
+	slice_Slice()
+	// Output:
+	// [1 2]
+	// [2 3 4]
+	// [1 2]
+	// [3 4 5]
+	// [4 5]
+	// [3]
+}
+
+// 配列を任意の値で埋める
+func Example_fill() {
+	slice_Fill()
+	// Output:
+	// [1 2 255 255 5]
+	// [1 0 0 255 5]
+}
+
+// 配列を空にする
+func Example_clear() {
+	slice_Clear()
+	// Output:
+	// []
+}
+
+// 配列同士を結合する
+func Example_concat() {
+	slice_Concat()
+	// Output:
+	// [1 2 3 4 5 10 20]
+}
+
+// 配列同士の和・積を取る
+func Example_union() {
+	slice_Union()
+	// Output:
+	// [1 2 3 4 5 6 7 8]
+	// [1 2 3 4 5 6]
+	// []
+	// [3 4]
+}
+
+// 複数の要素を変更する
+func Example_replace() {
+	slice_Replace()
+	// Output:
+	// [111 222 333 3 4 5]
+	// [111 222 333 444 555 5]
+}
+
+// 配列の配列をフラットな配列にする
+func Example_flatten() {
+	slice_Flatten()
+	// Output:
+	// [1 [2 [3 4] 5] [6 7]]
+	// [1 2 3 4 5 6 7]
+}
+
+// 配列をソートする
+func Example_sort() {
+	slice_Sort()
+	// Output:
+	// [1 2 3 4 5]
+	// [Apple Lemon Orange]
+}
+
+// 条件式を指定したソート
+func Example_caseSort() {
+	slice_CaseSort()
+	// Output:
+	// [Yoshi,0138 Hitoshi,045 Sizuo,046]
+}
+
+// 配列の配列を任意の要素でソートする
+func Example_sortAnyColumn() {
+	slice_SortAnyColumn()
+	// Output:
+	// [{1 c} {2 b} {3 a}]
+	// [{3 a} {2 b} {1 c}]
+}
+
+// 配列を逆順にする
+func Example_reverse() {
+	slice_Reverse()
+	// Output:
+	// [5 4 3 2 1]
+}
+
+// 配列を逆順にする (Go 1.21以降)
+func Example_reverse_go121() {
+	slice_Reverse_go1_21()
+	// Output:
+	// [5 4 3 2 1]
+}
+
+// 指定した位置の要素を取り除く
+func Example_delete() {
+	slice_Delete()
+	// Output:
+	// 5
+	// [1 4 2 3]
+	// 4
+	// [1 2 3]
+}
+
+// 一致する要素を全て取り除く
+func Example_deleteAll() {
+	slice_DeleteAll()
+	// Output:
+	// apple
+	// [orange lemon vine]
+	// <nil>
+	//
+	// [orange lemon vine]
+	// Couldn't find
+}
+
+// 配列から重複した要素を取り除く
+func Example_uniq() {
+	slice_Uniq()
+	// Output:
+	// [10 20 30 40 50]
+	// [/etc /home/ /tmp]
+}
+
+// 配列から指定条件を満たす要素を取り除く
+func Example_caseDelete() {
+	slice_CaseDelete()
+	// Output:
+	// [100 80 95]
+	// [100 95]
+}
+
+// 配列から指定条件を満たす要素を抽出する
+func Example_caseSelect() {
+	slice_CaseSelect()
+	// Output:
+	// [2 4]
+}
+
+// 配列中の要素を探す
+func Example_search() {
+	slice_Search()
+	// Output:
+	// 0
+	// <nil>
+	// 1
+	// <nil>
+	// -1
+	// Couldn't find
+}
+
+// 配列中の要素を探す (Go 1.21以降)
+func Example_search_go121() {
+	slice_Search_go1_21()
+	// Output:
+	// 0
+	// <nil>
+	// 1
+	// <nil>
+	// -1
+	// Couldn't find
+}
+
+// 配列の配列を検索する
+func Example_assoc() {
+	slice_Assoc()
+	// Output:
+	// [apple 100]
+	// [orange 300]
+	// []
+}
+
+// 配列の各要素にブロックを実行し配列を作成する
+func Example_block() {
+	slice_Block()
+	// Output:
+	// [100 200 300 400 500]
+	// [10 20 30 40 50]
+}
+
+// 配列の各要素に対して繰り返しブロックを実行する
+func Example_block2() {
+	slice_Block2()
+	// Output:
+	// Hello, Taro
+	// Hello, Hanako
+	// Hello, Ichiro
+}
+
+// 配列の要素の和を求める
+func Example_sum() {
+	slice_Sum()
+	// Output:
+	// 55
+}
+
+// 複数の配列を同時に動かす
+func Example_threeItems() {
+	slice_ThreeItems()
+	// Output:
+	// mango 200 5 1000
+	// apple 120 10 1200
+	// orange 100 12 1200
+}
+
+// 二次元，三次元の座標の配列の成分ごとの最大，最小を求める
+func Example_matMax() {
+	slice_MatMax()
+	// Output:
+	// 8
+	// 9
+}
+
+// 二次元，三次元の座標の配列の成分ごとの最大，最小を求める (Go 1.21以降)
+func Example_matMax_go121() {
+	slice_MatMax_go1_21()
+	// Output:
+	// 8
+	// 9
+}
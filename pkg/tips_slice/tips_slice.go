@@ -5,13 +5,15 @@
 package tips_slice
 
 import (
+	_ "embed"
 	"fmt"
 	set "github.com/deckarep/golang-set"
 	matrix "github.com/skelterjohn/go.matrix"
-	"math/rand"
+	"slices"
 	"sort"
 	"strings"
-	"time"
+
+	"github.com/ashitani/golangtips/pkg/tips"
 )
 
 //---------------------------------------------------
@@ -98,6 +100,9 @@ func slice_SliceOfSlice() {
 //---------------------------------------------------
 //配列要素をカンマ区切りで出力する
 //---------------------------------------------------
+/*
+文字列のスライスならstrings.Join()で連結できます。数値はfmt.Sprint()などで文字列にしてからつなぎます。
+*/
 // import "strings"
 
 func slice_Join() {
@@ -146,6 +151,9 @@ func slice_Join() {
 //---------------------------------------------------
 //配列の要素数を取得する
 //---------------------------------------------------
+/*
+len()で数えます。入れ子になった要素は1つと数えます。
+*/
 func slice_Count() {
 	fruits := []string{"apple", "orange", "lemon"}
 	fmt.Println(len(fruits)) // => "3"
@@ -316,26 +324,35 @@ func slice_Concat() {
 を使います。[]interface{}を受け取るようです。集合の内容は順不同みたいですね。
 
 和集合はUnion()、積集合はIntersect()で演算できます。
+そのまま表示すると実行のたびに順番が変わるので、ToSlice()で取り出して並べ替えています。
 */
 //import set "github.com/deckarep/golang-set"
+//import "sort"
 
 func slice_Union() {
 	a := set.NewSetFromSlice([]interface{}{1, 3, 5, 7})
 	b := set.NewSetFromSlice([]interface{}{2, 4, 6, 8})
-	fmt.Println(a.Union(b)) // => "Set{8, 5, 7, 1, 3, 2, 4, 6}"
+	fmt.Println(sorted(a.Union(b))) // => "[1 2 3 4 5 6 7 8]"
 
 	a = set.NewSetFromSlice([]interface{}{1, 2, 3, 4})
 	b = set.NewSetFromSlice([]interface{}{3, 4, 5, 6})
-	fmt.Println(a.Union(b)) // => "Set{3, 5, 6, 4, 1, 2}"
+	fmt.Println(sorted(a.Union(b))) // => "[1 2 3 4 5 6]"
 
 	a = set.NewSetFromSlice([]interface{}{1, 3, 5, 7})
 	b = set.NewSetFromSlice([]interface{}{2, 4, 6, 8})
-	fmt.Println(a.Intersect(b)) // => "Set{}"
+	fmt.Println(sorted(a.Intersect(b))) // => "[]"
 
 	a = set.NewSetFromSlice([]interface{}{1, 2, 3, 4})
 	b = set.NewSetFromSlice([]interface{}{3, 4, 5, 6})
-	fmt.Println(a.Intersect(b)) // => "Set{4,3}"
+	fmt.Println(sorted(a.Intersect(b))) // => "[3 4]"
+
+}
 
+// 集合の要素を、表示したときの文字列の順に並べる
+func sorted(s set.Set) []interface{} {
+	a := s.ToSlice()
+	sort.Slice(a, func(i, j int) bool { return fmt.Sprint(a[i]) < fmt.Sprint(a[j]) })
+	return a
 }
 
 //---------------------------------------------------
@@ -520,12 +537,29 @@ sort.Reverse()が使えます。ソートせずに逆順にするのはなさそ
 func slice_Reverse() {
 	a := []int{5, 1, 4, 2, 3}
 	sort.Sort(sort.Reverse(sort.IntSlice(a)))
-	fmt.Println(a) // => "[5,4,3,2,1]"
+	fmt.Println(a) // => "[5 4 3 2 1]"
+}
+
+//---------------------------------------------------
+//配列を逆順にする (Go 1.21以降)
+//---------------------------------------------------
+/*
+Go 1.21のslicesパッケージにReverse()があり、ソートせずに逆順にできます。
+元のTipsと同じように大きい順に並べるなら、Sort()してからReverse()します。
+*/
+func slice_Reverse_go1_21() {
+	a := []int{5, 1, 4, 2, 3}
+	slices.Sort(a)
+	slices.Reverse(a)
+	fmt.Println(a) // => "[5 4 3 2 1]"
 }
 
 //---------------------------------------------------
 //指定した位置の要素を取り除く
 //---------------------------------------------------
+/*
+スライスを詰め直す関数を書いています。Go 1.21からは[slices.Delete()](https://pkg.go.dev/slices#Delete)も使えます。
+*/
 func slice_Delete() {
 	a := []int{5, 1, 4, 2, 3}
 	d := 0
@@ -541,10 +575,10 @@ func slice_Delete() {
 }
 
 func delete(slice []int, i int) (int, []int, error) {
-	ret := slice[i]
-	if len(slice) < i || len(slice) < i {
+	if i < 0 || len(slice) <= i {
 		return 0, nil, fmt.Errorf("Error")
 	}
+	ret := slice[i]
 	ans := make([]int, len(slice))
 	copy(ans, slice)
 
@@ -594,18 +628,20 @@ func delete_strings(slice []string, s string) (string, []string, error) {
 //---------------------------------------------------
 /*
 これも[golang-set](https://github.com/deckarep/golang-set)
-を使います。
+を使います。集合は順不同なので、[配列同士の和・積を取る](#slice_Union)の
+sorted()で並べ替えて表示します。
 */
 //import set "github.com/deckarep/golang-set"
+//import "sort"
 
 func slice_Uniq() {
 	a := []interface{}{30, 20, 50, 30, 10, 10, 40, 50}
 	as := set.NewSetFromSlice(a)
-	fmt.Println(as) // => Set{30,20,50,10,40}
+	fmt.Println(sorted(as)) // => "[10 20 30 40 50]"
 
 	s := []interface{}{"/tmp", "/home/", "/etc", "/tmp"}
 	ss := set.NewSetFromSlice(s)
-	fmt.Println(ss) // =>Set{/tmp, /home/, /etc}
+	fmt.Println(sorted(ss)) // => "[/etc /home/ /tmp]"
 }
 
 //---------------------------------------------------
@@ -718,6 +754,39 @@ func index(a, query any) (int, error) {
 	return -1, fmt.Errorf("Couldn't find")
 }
 
+//---------------------------------------------------
+//配列中の要素を探す (Go 1.21以降)
+//---------------------------------------------------
+/*
+Go 1.21のslicesパッケージのIndex()は、==で比べられる要素ならinterface{}の配列でも探せます。
+見つからなければ-1です。あるかどうかだけならContains()を使います。
+元のTipsのindex()もこれで短く書けます。
+*/
+func slice_Search_go1_21() {
+	a := any{"apple", 10, "orange", any{"lemon", "vine"}}
+
+	i, err := search(a, "apple")
+	fmt.Println(i)   // => "0"
+	fmt.Println(err) // => "<nil>"
+
+	i, err = search(a, 10)
+	fmt.Println(i)   // => "1"
+	fmt.Println(err) // => "<nil>"
+
+	i, err = search(a, "fruit")
+	fmt.Println(i)   // => "-1"
+	fmt.Println(err) // => "Couldn't find"
+}
+
+// index()をslices.Index()で書き直したもの
+func search(a any, x interface{}) (int, error) {
+	i := slices.Index(a, x)
+	if i < 0 {
+		return -1, fmt.Errorf("Couldn't find")
+	}
+	return i, nil
+}
+
 //---------------------------------------------------
 //配列の配列を検索する
 //---------------------------------------------------
@@ -785,6 +854,9 @@ func slice_Block2() {
 //---------------------------------------------------
 //配列の要素の和を求める
 //---------------------------------------------------
+/*
+sumのような関数はないので、rangeで足していきます。
+*/
 func slice_Sum() {
 	a := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
 	sum := 0
@@ -797,6 +869,9 @@ func slice_Sum() {
 //---------------------------------------------------
 //配列の要素をランダムに抽出する
 //---------------------------------------------------
+/*
+rand.Intn()で添字を選びます。
+*/
 // import "time"
 // import "math/rand"
 func slice_Choice() {
@@ -806,14 +881,29 @@ func slice_Choice() {
 }
 
 func choice(s []int) int {
-	rand.Seed(time.Now().UnixNano())
-	i := rand.Intn(len(s))
+	tips.Rand.Seed(tips.Clock.Now().UnixNano())
+	i := tips.Rand.Intn(len(s))
 	return s[i]
 }
 
+//---------------------------------------------------
+//配列の要素をランダムに抽出する (Go 1.20以降)
+//---------------------------------------------------
+/*
+Go 1.20からはSeed()がいらないので、その場でIntn()で選べます。
+*/
+func slice_Choice_go1_20() {
+	a := []int{1, 2, 3}
+	fmt.Println(a[tips.Rand.Intn(len(a))])
+	fmt.Println(a[tips.Rand.Intn(len(a))])
+}
+
 //---------------------------------------------------
 //複数の配列を同時に動かす
 //---------------------------------------------------
+/*
+Rubyのzipはないので、添字を共有してループします。長さが揃っていることを確かめてから使いましょう。
+*/
 
 func slice_ThreeItems() {
 	fruits := []string{"mango", "apple", "orange"}
@@ -855,39 +945,102 @@ func max(a []float64) float64 {
 }
 
 //---------------------------------------------------
-// 配列
+//二次元，三次元の座標の配列の成分ごとの最大，最小を求める (Go 1.21以降)
 //---------------------------------------------------
-func Tips_slice() {
-
-	slice_Define()        // プログラムで配列を定義する
-	slice_SliceOfSlice()  // m x n 行列の形で配列の配列を初期化する
-	slice_Join()          // 配列要素をカンマ区切りで出力する
-	slice_Count()         //配列の要素数を取得する
-	slice_Append()        //配列に要素を追加する
-	slice_Pop()           //配列の先頭または末尾から要素を取りだす
-	slice_Slice()         //部分配列を取りだす
-	slice_Fill()          //配列を任意の値で埋める
-	slice_Clear()         //配列を空にする
-	slice_Concat()        //配列同士を結合する
-	slice_Union()         //配列同士の和・積を取る
-	slice_Replace()       //複数の要素を変更する
-	slice_Flatten()       //配列の配列をフラットな配列にする
-	slice_Sort()          //配列をソートする
-	slice_CaseSort()      //条件式を指定したソート
-	slice_SortAnyColumn() //配列の配列を任意の要素でソートする
-	slice_Reverse()       //配列を逆順にする
-	slice_Delete()        //指定した位置の要素を取り除く
-	slice_DeleteAll()     //一致する要素を全て取り除く
-	slice_Uniq()          //配列から重複した要素を取り除く
-	slice_CaseDelete()    //配列から指定条件を満たす要素を取り除く
-	slice_CaseSelect()    //配列から指定条件を満たす要素を抽出する
-	slice_Search()        //配列中の要素を探す
-	slice_Assoc()         //配列の配列を検索する
-	slice_Block()         //配列の各要素にブロックを実行し配列を作成する
-	slice_Block2()        //配列の各要素に対して繰り返しブロックを実行する
-	slice_Sum()           //配列の要素の和を求める
-	slice_Choice()        //配列の要素をランダムに抽出する
-	slice_ThreeItems()    //複数の配列を同時に動かす
-	slice_MatMax()        //二次元，三次元の座標の配列の成分ごとの最大，最小を求める
+/*
+Go 1.21からはslicesパッケージのMax()が使えるので、max()を書く必要はありません。
+最小はMin()です。
+*/
+func slice_MatMax_go1_21() {
+	a, _ := matrix.ParseMatlab("[1 5;8 4;2 9;4 3]")
+	x := a.ColCopy(0)
+	y := a.ColCopy(1)
+	fmt.Println(slices.Max(x)) // => "8"
+	fmt.Println(slices.Max(y)) // => "9"
+}
 
+//---------------------------------------------------
+// 配列
+//---------------------------------------------------
+//go:embed tips_slice.go
+var source []byte
+
+func init() {
+	tips.Register("pkg/tips_slice/tips_slice.go", "配列", source, map[string]func(){
+		"slice_Define":         slice_Define,
+		"slice_SliceOfSlice":   slice_SliceOfSlice,
+		"slice_Join":           slice_Join,
+		"slice_Count":          slice_Count,
+		"slice_Append":         slice_Append,
+		"slice_Pop":            slice_Pop,
+		"slice_Slice":          slice_Slice,
+		"slice_Fill":           slice_Fill,
+		"slice_Clear":          slice_Clear,
+		"slice_Concat":         slice_Concat,
+		"slice_Union":          slice_Union,
+		"slice_Replace":        slice_Replace,
+		"slice_Flatten":        slice_Flatten,
+		"slice_Sort":           slice_Sort,
+		"slice_CaseSort":       slice_CaseSort,
+		"slice_SortAnyColumn":  slice_SortAnyColumn,
+		"slice_Reverse":        slice_Reverse,
+		"slice_Reverse_go1_21": slice_Reverse_go1_21,
+		"slice_Delete":         slice_Delete,
+		"slice_DeleteAll":      slice_DeleteAll,
+		"slice_Uniq":           slice_Uniq,
+		"slice_CaseDelete":     slice_CaseDelete,
+		"slice_CaseSelect":     slice_CaseSelect,
+		"slice_Search":         slice_Search,
+		"slice_Search_go1_21":  slice_Search_go1_21,
+		"slice_Assoc":          slice_Assoc,
+		"slice_Block":          slice_Block,
+		"slice_Block2":         slice_Block2,
+		"slice_Sum":            slice_Sum,
+		"slice_Choice":         slice_Choice,
+		"slice_Choice_go1_20":  slice_Choice_go1_20,
+		"slice_ThreeItems":     slice_ThreeItems,
+		"slice_MatMax":         slice_MatMax,
+		"slice_MatMax_go1_21":  slice_MatMax_go1_21,
+	})
+
+	// 逆引きRubyで対応するメソッド
+	tips.SetRuby(map[string][]string{
+		"slice_Define":        []string{"Array.new", "Array.[]"},
+		"slice_SliceOfSlice":  []string{"Array.new"},
+		"slice_Join":          []string{"Array#join"},
+		"slice_Count":         []string{"Array#length", "Array#size"},
+		"slice_Append":        []string{"Array#push", "Array#<<"},
+		"slice_Pop":           []string{"Array#pop", "Array#shift"},
+		"slice_Slice":         []string{"Array#[]", "Array#slice"},
+		"slice_Fill":          []string{"Array#fill"},
+		"slice_Clear":         []string{"Array#clear"},
+		"slice_Concat":        []string{"Array#+", "Array#concat"},
+		"slice_Union":         []string{"Array#|", "Array#&"},
+		"slice_Replace":       []string{"Array#[]="},
+		"slice_Flatten":       []string{"Array#flatten"},
+		"slice_Sort":          []string{"Array#sort"},
+		"slice_CaseSort":      []string{"Array#sort", "Array#sort_by"},
+		"slice_SortAnyColumn": []string{"Array#sort_by"},
+		"slice_Reverse":       []string{"Array#reverse"},
+		"slice_Delete":        []string{"Array#delete_at"},
+		"slice_DeleteAll":     []string{"Array#delete"},
+		"slice_Uniq":          []string{"Array#uniq"},
+		"slice_CaseDelete":    []string{"Array#delete_if", "Array#reject"},
+		"slice_CaseSelect":    []string{"Array#select"},
+		"slice_Search":        []string{"Array#index", "Array#include?"},
+		"slice_Assoc":         []string{"Array#assoc", "Array#rassoc"},
+		"slice_Block":         []string{"Array#collect", "Array#map"},
+		"slice_Block2":        []string{"Array#each", "Array#each_with_index"},
+		"slice_Sum":           []string{"Array#inject", "Array#sum"},
+		"slice_Choice":        []string{"Array#sample"},
+		"slice_ThreeItems":    []string{"Array#zip"},
+		"slice_MatMax":        []string{"Array#transpose", "Array#max", "Array#min"},
+	})
+
+	// 中心になる補助関数のベンチマーク
+	a := any{"apple", 10, "orange", any{"lemon", "vine"}}
+	tips.SetBenchmarks(map[string]func(){
+		"slice_Search":        func() { index(a, any{"fruit"}) },
+		"slice_Search_go1_21": func() { search(a, "fruit") },
+	})
 }
@@ -0,0 +1,343 @@
+// Code generated by make_examples; DO NOT EDIT.
+
+package tips_string
+
+import (
+	"time"
+
+	"github.com/ashitani/golangtips/pkg/tips/input"
+)
+
+// 次のTipsはExampleにできないので生成していません。
+//
+//   string_Repeat: 行末の空白や連続した空行は // Output: で表せない
+//   string_Exec: 外部コマンドを実行する (exec.Command)
+//   string_HereDocument: 行末の空白や連続した空行は // Output: で表せない
+//   string_HereDocumentIndent: 行末の空白や連続した空行は // Output: で表せない
+//   string_ExecMultiLine: 外部コマンドを実行する (exec.Command)
+//   string_Just: 行末の空白や連続した空行は // Output: で表せない
+//   string_Kconv: UTF-8でない出力はソースに書けない
+
+func init() {
+	time.Local = time.FixedZone("JST", 9*60*60)
+}
+
+// 文字列を結合する
+func Example_concat() {
+	string_Concat()
+	// Output:
+	// Hello World
+}
+
+// 大文字・小文字に揃える
+func Example_upperLower() {
+	string_UpperLower()
+	// Output:
+	// I LOVE GOLANG
+	// i love golang
+}
+
+// 大文字と小文字の入れ替え
+func Example_replaceUpperLower() {
+	string_ReplaceUpperLower()
+	// Output:
+	// I Love Golang
+	// ｇＯ言語
+	// αΒγ
+	// STRASSE
+}
+
+// 部分文字列を取り出す
+func Example_extract() {
+	string_Extract()
+	// Output:
+	// Apple
+	// Banana
+	// App
+	// 66
+	// Orange
+}
+
+// 部分文字列を置き換える
+func Example_replacePart() {
+	string_ReplacePart()
+	// Output:
+	// Vine  Banana Orange
+}
+
+// 文字を置き換える
+func Example_tr() {
+	string_Tr()
+	// Output:
+	// hippo
+	// h*ll*
+	// hAll*
+	// ifmmp
+	// *e**o
+	// ho
+	// hello_world
+	// hero
+	// h-o
+	// *bb*
+	// コンニチハ
+	// 123円
+	// invalid range "z-a" in string transliteration
+}
+
+// 指定した文字を削除する・まとめる・数える
+func Example_delete() {
+	string_Delete()
+	// Output:
+	// heo
+	// he
+	// hell
+	// ho
+	// 09012345678
+	// yelow mon
+	//  now is the
+	// puters shot balls
+	// ええーっ！
+	// 5
+	// 2
+	// 4
+	// 4
+	// 4
+	// 4
+	// 8
+}
+
+// 文字列中の式を評価し値を展開する
+func Example_eval() {
+	string_Eval()
+	// Output:
+	// value is 123
+}
+
+// 文字列を1文字ずつ処理する
+func Example_each() {
+	string_Each()
+	// Output:
+	// 600
+}
+
+// 文字列の先頭と末尾の空白文字を削除する
+func Example_trim() {
+	string_Trim()
+	// Output:
+	// Hello, Golang!
+}
+
+// 文字列を整数に変換する (to_i)
+func Example_toI() {
+	string_ToI()
+	// Output:
+	// 1000
+}
+
+// 文字列を浮動小数点に変換する (to_f)
+func Example_toF() {
+	string_ToF()
+	// Output:
+	// 10
+}
+
+// 8進文字列を整数に変換する
+func Example_parseOct() {
+	string_ParseOct()
+	// Output:
+	// 8
+}
+
+// 16進文字列を整数に変換する
+func Example_parseHex() {
+	string_ParseHex()
+	// Output:
+	// 255
+	// 255
+}
+
+// ASCII文字をコード値に（コード値をASCII文字に）変換する
+func Example_atoI() {
+	string_AtoI()
+	// Output:
+	// 65
+	// R
+}
+
+// 全角文字を含む文字列を表示幅で揃える
+func Example_justWidth() {
+	string_JustWidth()
+	// Output:
+	// 6
+	// [日本語    ]
+	// [    日本語]
+	// *******日本語*******
+	// 12abc121
+	// ・ Go・・
+	// 逆引きGol...
+	// 日本
+	// 2 2
+	// 3 6
+	// |鈴木一郎太  |  1234|
+	// |Bob         |  1235|
+	// |佐藤花子    |  1236|
+}
+
+// "次"の文字列を取得する
+func Example_succ() {
+	string_Succ()
+	// Output:
+	// 10
+	// b
+	// AAB
+	// B00
+	// A100
+	// abce
+	// THX1139
+	// <<koalb>>
+	// 2000aaa
+	// AAAA0000
+	// **+
+	// AAa
+	// aaa00aa00
+	// 100aa00aa
+	// 2.0.0
+	// No.10
+	// a-10
+	// !
+	// ぃ
+	// あaa
+	// ｛
+	// Ｇp９
+}
+
+// "前"の文字列を取得する
+func Example_pred() {
+	string_Pred()
+	// Output:
+	// abcd
+	// A99
+	// 9
+	// Zz
+	// 1999zzz
+	// ZZZ9999
+	// a-9
+	// <<koala>>
+	// ***
+	// ａ｀
+	// true
+}
+
+// 範囲内の文字列を順に取り出す
+func Example_upto() {
+	string_Upto()
+	// Output:
+	// [a b c d e]
+	// [a b c d]
+	// [9 10 11]
+	// [07 08 09 10 11]
+	// [a8 a9 b0 b1 b2]
+	// 0
+}
+
+// 文字列を暗号化する
+func Example_crypt() {
+	defer input.Feed(input.MustParse("line hogehoge\n"))()
+	string_Crypt()
+	// Output:
+	// input password >right
+}
+
+// 文字列中で指定したパターンにマッチする部分を置換する
+func Example_replace() {
+	string_Replace()
+	// Output:
+	// Pine Banana Apple Orange
+	// Pine Banana Pine Orange
+}
+
+// 文字列中に含まれている任意文字列の位置を求める
+func Example_find() {
+	string_Find()
+	// Output:
+	// 0
+	// 6
+	// 13
+	// 13
+	// 0
+}
+
+// 文字列の末端の改行を削除する
+func Example_chomp() {
+	string_Chomp()
+	// Output:
+	// Hello, Golang!
+}
+
+// カンマ区切りの文字列を扱う
+func Example_split() {
+	string_Split()
+	// Output:
+	// 001
+	// ASHITANI Tatsuji
+	// Yokohama
+}
+
+// 任意のパターンにマッチするものを全て抜き出す
+func Example_findAll() {
+	string_FindAll()
+	// Output:
+	// [[hoge:045-111-2222 hoge 045-111-2222] [boke:045-222-2222 boke 045-222-2222]]
+}
+
+// 漢字コードを判定する
+func Example_kconvGuess() {
+	string_KconvGuess()
+	// Output:
+	// Shift_JIS
+	// EUC-JP
+	// ISO-2022-JP
+	// UTF-8 (BOM)
+	// 漢字です
+	// true
+}
+
+// 全角と半角の英数字・記号を変換する
+func Example_zenkaku() {
+	string_Zenkaku()
+	// Output:
+	// ABC 123!@# カナ
+	// Ｇｏ　１．４　（２０１４）
+}
+
+// 半角カナと全角カナを変換する
+func Example_zenkakuKana() {
+	string_ZenkakuKana()
+	// Output:
+	// ガギグ パピプ ヴァイオリン コンニチハ。
+	// ｶﾞｷﾞｸﾞ ﾊﾟﾋﾟﾌﾟ ｳﾞｧｲｵﾘﾝ ｺﾝﾆﾁﾊ。
+	// データ
+}
+
+// ひらがなとカタカナを変換する
+func Example_katakana() {
+	string_Katakana()
+	// Output:
+	// ヒラガナヲカタカナニ。ヴァイオリン
+	// かたかなをひらがなに。ゞ
+}
+
+// マルチバイト文字の数を数える
+func Example_count() {
+	string_Count()
+	// Output:
+	// 9
+	// 3
+}
+
+// マルチバイト文字列の最後の1文字を削除する
+func Example_chopRune() {
+	string_ChopRune()
+	// Output:
+	// 日本
+}
@@ -8,9 +8,10 @@ import (
 	"bufio"
 	"bytes"
 	"crypto/md5"
+	_ "embed"
 	"fmt"
 	. "github.com/MakeNowJust/heredoc/dot"
-	. "golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/japanese"
 	"golang.org/x/text/transform"
 	"io"
 	"os"
@@ -18,11 +19,20 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+	"github.com/ashitani/golangtips/pkg/tips/cellwidth"
+	"github.com/ashitani/golangtips/pkg/tips/kconv"
+	"github.com/ashitani/golangtips/pkg/tips/rstring"
 )
 
 //---------------------------------------------------
 // 文字列を結合する
 //---------------------------------------------------
+/*
++でつなぎます。たくさんつなぐ場合はstrings.Builderを使うと速いです。
+*/
 func string_Concat() {
 	a := "Hello"
 	b := a + " World"
@@ -32,6 +42,9 @@ func string_Concat() {
 //---------------------------------------------------
 // 繰り返し文字列を生成する
 //---------------------------------------------------
+/*
+strings.Repeat()を使います。
+*/
 //  import "strings"
 
 func string_Repeat() {
@@ -42,6 +55,9 @@ func string_Repeat() {
 //---------------------------------------------------
 // 大文字・小文字に揃える
 //---------------------------------------------------
+/*
+strings.ToUpper()、strings.ToLower()を使います。
+*/
 //import "strings"
 
 func string_UpperLower() {
@@ -53,30 +69,26 @@ func string_UpperLower() {
 //---------------------------------------------------
 // 大文字と小文字の入れ替え
 //---------------------------------------------------
-//import "strings"
+/*
+1文字ずつ、大文字(とǅのようなタイトルケース)は小文字に、小文字は大文字にします。
+RubyのString#swapcaseと同じく、ßのように大文字にすると2文字になるものは
+golang.org/x/text/casesで変換します。pkg/tips/rstringに用意しました。
+*/
+//import "github.com/ashitani/golangtips/pkg/tips/rstring"
 
 func string_ReplaceUpperLower() {
-	//うーん、かなりイマイチ
-	s := "i lOVE gOLANG"
-	sr := ""
-	for _, x := range s {
-		xs := string(x)
-		u := strings.ToUpper(xs)
-		l := strings.ToLower(xs)
-		if u == xs {
-			sr += l
-		} else if l == xs {
-			sr += u
-		} else {
-			sr += xs
-		}
-	}
-	fmt.Println(sr)
+	fmt.Println(rstring.Swapcase("i lOVE gOLANG")) // => "I Love Golang"
+	fmt.Println(rstring.Swapcase("Ｇｏ言語"))          // => "ｇＯ言語"
+	fmt.Println(rstring.Swapcase("ΑβΓ"))           // => "αΒγ"
+	fmt.Println(rstring.Swapcase("straße"))        // => "STRASSE"
 }
 
 //---------------------------------------------------
 // コマンドの実行結果を文字列に
 //---------------------------------------------------
+/*
+exec.Command()で作ったコマンドのOutput()が標準出力のバイト列を返します。
+*/
 // import "os/exec"
 
 func string_Exec() {
@@ -87,6 +99,9 @@ func string_Exec() {
 //---------------------------------------------------
 // 複数行の文字列を作成する
 //---------------------------------------------------
+/*
+バッククォートで囲んだ文字列は改行を含められ、エスケープもされません。
+*/
 func string_HereDocument() {
 	s := `
 	This is a test.
@@ -121,6 +136,8 @@ func string_HereDocumentIndent() {
 (echoはシェル組み込みコマンドのせいか実行されない。。)
 */
 // import "os/exec"
+// import "strings"
+// import . "github.com/MakeNowJust/heredoc/dot"
 
 func string_ExecMultiLine() {
 	s := D(`
@@ -139,6 +156,9 @@ func string_ExecMultiLine() {
 //---------------------------------------------------
 // 部分文字列を取り出す
 //---------------------------------------------------
+/*
+スライスで取り出します。添字はバイト単位で、s[6]のように1つだけ取り出すとバイトの値になります。
+*/
 func string_Extract() {
 	s := "Apple Banana Orange"
 	fmt.Println(s[0:5])       // => Apple
@@ -165,6 +185,78 @@ func string_ReplacePart() {
 	fmt.Println(strings.Join(ss, ""))
 }
 
+//---------------------------------------------------
+// 文字を置き換える
+//---------------------------------------------------
+/*
+RubyのString#trとtr_sです。fromの文字を、toの同じ位置の文字に置き換えます。
+pkg/tips/rstringに、正規表現と同じく指定を先に解釈しておく`Translator`を用意しました。
+
+fromとtoには次の書き方が使えます。この書き方は次のTipsの`CharSet`でも使います。
+
+- `a-z`: 範囲。`z-a`のように逆順だと`NewTranslator()`がエラーを返します(`MustTranslator()`はpanic)
+- `^aeiou`: 先頭の^で、書いた文字以外(toでは使えません)
+- `\-`、`\^`、`\\`: \の次の文字はそのまま。先頭と末尾の-もそのままです
+
+toがfromより短ければ、toの最後の文字を繰り返したものとします。toが空なら取り除きます。
+TrS()は、置き換えた結果が同じ文字になって続くところを1文字にまとめます。
+
+1文字ずつruneで扱うので、日本語にも使えます。
+*/
+//import "github.com/ashitani/golangtips/pkg/tips/rstring"
+
+func string_Tr() {
+	fmt.Println(rstring.MustTranslator("el", "ip").Tr("hello"))      // => "hippo"
+	fmt.Println(rstring.MustTranslator("aeiou", "*").Tr("hello"))    // => "h*ll*"
+	fmt.Println(rstring.MustTranslator("aeiou", "AA*").Tr("hello"))  // => "hAll*"
+	fmt.Println(rstring.MustTranslator("a-y", "b-z").Tr("hello"))    // => "ifmmp"
+	fmt.Println(rstring.MustTranslator("^aeiou", "*").Tr("hello"))   // => "*e**o"
+	fmt.Println(rstring.MustTranslator("el", "").Tr("hello"))        // => "ho"
+	fmt.Println(rstring.MustTranslator(`\-`, "_").Tr("hello-world")) // => "hello_world"
+	fmt.Println(rstring.MustTranslator("l", "r").TrS("hello"))       // => "hero"
+	fmt.Println(rstring.MustTranslator("el", "-").TrS("hello"))      // => "h-o"
+	fmt.Println(rstring.MustTranslator("^b", "*").TrS("aabbcc"))     // => "*bb*"
+
+	// マルチバイト文字
+	fmt.Println(rstring.MustTranslator("ぁ-ん", "ァ-ン").Tr("こんにちは")) // => "コンニチハ"
+	fmt.Println(rstring.MustTranslator("０-９", "0-9").Tr("１２３円"))  // => "123円"
+
+	// 逆順の範囲はエラー
+	_, err := rstring.NewTranslator("z-a", "*")
+	fmt.Println(err) // => invalid range "z-a" in string transliteration
+}
+
+//---------------------------------------------------
+// 指定した文字を削除する・まとめる・数える
+//---------------------------------------------------
+/*
+RubyのString#delete、squeeze、countです。文字の指定は前のTipsと同じ書き方で、
+`rstring.NewCharSet()`や`rstring.MustCharSet()`に複数渡すと、そのすべてに含まれる文字が対象になります。
+何も渡さなければ、すべての文字が対象です。
+*/
+//import "github.com/ashitani/golangtips/pkg/tips/rstring"
+
+func string_Delete() {
+	fmt.Println(rstring.MustCharSet("l", "lo").Delete("hello"))            // => "heo"
+	fmt.Println(rstring.MustCharSet("lo").Delete("hello"))                 // => "he"
+	fmt.Println(rstring.MustCharSet("aeiou", "^e").Delete("hello"))        // => "hell"
+	fmt.Println(rstring.MustCharSet("ej-m").Delete("hello"))               // => "ho"
+	fmt.Println(rstring.MustCharSet("^0-9").Delete("090-1234-5678"))       // => "09012345678"
+	fmt.Println(rstring.MustCharSet().Squeeze("yellow moon"))              // => "yelow mon"
+	fmt.Println(rstring.MustCharSet(" ").Squeeze("  now   is  the"))       // => " now is the"
+	fmt.Println(rstring.MustCharSet("m-z").Squeeze("putters shoot balls")) // => "puters shot balls"
+	fmt.Println(rstring.MustCharSet("ー！").Squeeze("ええーーっ！！"))              // => "ええーっ！"
+
+	a := "hello world"
+	fmt.Println(rstring.MustCharSet("lo").Count(a))                  // => "5"
+	fmt.Println(rstring.MustCharSet("lo", "o").Count(a))             // => "2"
+	fmt.Println(rstring.MustCharSet("hello", "^l").Count(a))         // => "4"
+	fmt.Println(rstring.MustCharSet("ej-m").Count(a))                // => "4"
+	fmt.Println(rstring.MustCharSet(`\^aeiou`).Count("hello^world")) // => "4"
+	fmt.Println(rstring.MustCharSet(`a\-eo`).Count("hello-world"))   // => "4"
+	fmt.Println(rstring.MustCharSet("も").Count("すもももももももものうち"))      // => "8"
+}
+
 //---------------------------------------------------
 // 文字列中の式を評価し値を展開する
 //---------------------------------------------------
@@ -204,6 +296,9 @@ func string_Trim() {
 //---------------------------------------------------
 // 文字列を整数に変換する (to_i)
 //---------------------------------------------------
+/*
+strconv.Atoi()を使います。変換できない場合はエラーが返ります。
+*/
 //import "strconv"
 
 func string_ToI() {
@@ -217,6 +312,9 @@ func string_ToI() {
 //---------------------------------------------------
 // 文字列を浮動小数点に変換する (to_f)
 //---------------------------------------------------
+/*
+strconv.ParseFloat()を使います。2番目の引数はビット数です。
+*/
 //import "strconv"
 
 func string_ToF() {
@@ -228,6 +326,9 @@ func string_ToF() {
 //---------------------------------------------------
 // 8進文字列を整数に変換する
 //---------------------------------------------------
+/*
+strconv.ParseInt()の2番目の引数に基数を渡します。
+*/
 //import "strconv"
 
 func string_ParseOct() {
@@ -260,16 +361,23 @@ func string_ParseHex() {
 //---------------------------------------------------
 // ASCII文字をコード値に（コード値をASCII文字に）変換する
 //---------------------------------------------------
+/*
+文字列をs[0]のように添字で取り出すとバイトの値になります。逆はstring(rune(82))です。
+*/
 func string_AtoI() {
 	s := "ABC"
 	fmt.Println(s[0])
-	fmt.Println(string(82))
+	fmt.Println(string(rune(82)))
 }
 
 //---------------------------------------------------
 // 文字列を中央寄せ・左詰・右詰する
 //---------------------------------------------------
+/*
+`%10s`などの幅は文字数(rune)で数えます。全角文字を含む文字列を揃えるには次のTipsを使います。
+*/
 //import "strings"
+//import "unicode/utf8"
 
 func string_Just() {
 	//右詰、左詰めはformat文字列が対応しています。±で指定。
@@ -279,71 +387,166 @@ func string_Just() {
 
 	//センタリングはなさそうです。
 	l := 10
-	ls := (l - len(s)) / 2
-	cs := strings.Repeat(" ", ls) + s + strings.Repeat(" ", l-(ls+len(s)))
+	n := utf8.RuneCountInString(s)
+	ls := (l - n) / 2
+	cs := strings.Repeat(" ", ls) + s + strings.Repeat(" ", l-(ls+n))
 	fmt.Println(cs)
 }
 
 //---------------------------------------------------
-// "次"の文字列を取得する
+// 全角文字を含む文字列を表示幅で揃える
 //---------------------------------------------------
-func string_Succ() {
-	fmt.Println(succ("9"))    // => "10"
-	fmt.Println(succ("a"))    // => "b"
-	fmt.Println(succ("AAA"))  // => "AAB"
-	fmt.Println(succ("A99"))  // => "B00"
-	fmt.Println(succ("A099")) // => "A100"
-}
-
-// 文字列を反転して返す
-func reverse(s string) string {
-	ans := ""
-	for i, _ := range s {
-		ans += string(s[len(s)-i-1])
+/*
+端末や等幅フォントでの表示幅(セル数)で数えて、左詰・右詰・中央寄せ・切り詰めをする
+パッケージを、pkg/tips/cellwidthに用意しました。
+
+- 全角文字(East Asian WideとFullwidth)は2、結合文字や制御文字は0、それ以外は1と数えます。
+- ○や①、ギリシャ文字などの「曖昧な幅」の文字は、環境によって1にも2にもなります。
+  パッケージの関数は1と数え、2と数えるには`cellwidth.Counter{Ambiguous: 2}`のメソッドを使います。
+- 詰め物にはRubyの`center(20, "*")`のように任意の文字列を使えます。入りきらない全角文字は空白にします。
+
+幅の種類はgolang.org/x/text/widthで調べられます。
+*/
+//import "github.com/ashitani/golangtips/pkg/tips/cellwidth"
+
+func string_JustWidth() {
+	fmt.Println(cellwidth.Width("Go言語"))                          // => "6"
+	fmt.Println("[" + cellwidth.Ljust("日本語", 10, " ") + "]")      // => "[日本語    ]"
+	fmt.Println("[" + cellwidth.Rjust("日本語", 10, " ") + "]")      // => "[    日本語]"
+	fmt.Println(cellwidth.Center("日本語", 20, "*"))                 // => "*******日本語*******"
+	fmt.Println(cellwidth.Center("abc", 8, "12"))                 // => "12abc121"
+	fmt.Println(cellwidth.Center("Go", 9, "・"))                   // => "・ Go・・"
+	fmt.Println(cellwidth.Truncate("逆引きGolangのTips集", 12, "...")) // => "逆引きGol..."
+	fmt.Println(cellwidth.Truncate("日本語", 5, ""))                 // => "日本"
+
+	// 結合文字の濁点は幅0
+	fmt.Println(cellwidth.Width("が"), cellwidth.Width("か\u3099")) // => "2 2"
+
+	// 曖昧な幅の文字
+	wide := cellwidth.Counter{Ambiguous: 2}
+	fmt.Println(cellwidth.Width("①②③"), wide.Width("①②③")) // => "3 6"
+
+	// 表にする
+	staff := []struct {
+		name string
+		dept int
+	}{
+		{"鈴木一郎太", 1234},
+		{"Bob", 1235},
+		{"佐藤花子", 1236},
+	}
+	for _, s := range staff {
+		fmt.Printf("|%s|%s|\n", cellwidth.Ljust(s.name, 12, " "), cellwidth.Rjust(fmt.Sprint(s.dept), 6, " "))
 	}
-	return string(ans)
 }
 
+//---------------------------------------------------
 // "次"の文字列を取得する
-func succ(s string) string {
-	r := reverse(s)
-	ans := ""
-	carry := 1
-	lastLetter := string(r[0])
-	for i, _ := range r {
-		lastLetter = string(r[i])
-		a := lastLetter
-		if carry == 1 {
-			if lastLetter == "z" {
-				a = "a"
-				carry = 1
-			} else if lastLetter == "Z" {
-				a = "A"
-				carry = 1
-			} else if lastLetter == "9" {
-				a = "0"
-				carry = 1
-			} else {
-				if r[i] == 0 {
-					a = "1"
-				} else {
-					a = string(r[i] + 1)
-					carry = 0
-				}
-			}
-		}
-		ans += a
-	}
-	if carry == 1 {
-		if lastLetter == "9" {
-			ans += "1"
-		} else if lastLetter == "z" {
-			ans += "a"
-		} else if lastLetter == "Z" {
-			ans += "A"
-		}
-	}
-	return reverse(ans)
+//---------------------------------------------------
+/*
+RubyのString#succと同じ規則で"次"の文字列を作る関数を、pkg/tips/rstringに用意しました。
+
+- 一番右の英数字を1つ進めます。z、Z、9のように桁あふれしたら、左の英数字に繰り上げます。
+- 英数字の間の記号は飛ばして繰り上げます。ただし、数字と英字が記号で区切られている所では
+  繰り上げずに、そこに文字を足します("No.9"が"No.10"になる)。
+- 左端まで繰り上がったら、a、A、1のうち桁あふれした文字に合わせたものを足します。
+- 英数字がなければ、一番右の文字を文字コードで1つ進めます。空文字列の次は空文字列です。
+
+Rubyと同じく英数字として扱うのはASCIIだけで、"あ"や全角の"ｚ"は記号と同じく文字コードで進めます。
+*/
+//import "github.com/ashitani/golangtips/pkg/tips/rstring"
+
+func string_Succ() {
+	fmt.Println(rstring.Succ("9"))    // => "10"
+	fmt.Println(rstring.Succ("a"))    // => "b"
+	fmt.Println(rstring.Succ("AAA"))  // => "AAB"
+	fmt.Println(rstring.Succ("A99"))  // => "B00"
+	fmt.Println(rstring.Succ("A099")) // => "A100"
+
+	// Rubyのドキュメントとテストにある例
+	fmt.Println(rstring.Succ("abcd"))      // => "abce"
+	fmt.Println(rstring.Succ("THX1138"))   // => "THX1139"
+	fmt.Println(rstring.Succ("<<koala>>")) // => "<<koalb>>"
+	fmt.Println(rstring.Succ("1999zzz"))   // => "2000aaa"
+	fmt.Println(rstring.Succ("ZZZ9999"))   // => "AAAA0000"
+	fmt.Println(rstring.Succ("***"))       // => "**+"
+	fmt.Println(rstring.Succ("Zz"))        // => "AAa"
+	fmt.Println(rstring.Succ("zz99zz99"))  // => "aaa00aa00"
+	fmt.Println(rstring.Succ("99zz99zz"))  // => "100aa00aa"
+	fmt.Println(rstring.Succ("1.9.9"))     // => "2.0.0"
+	fmt.Println(rstring.Succ("No.9"))      // => "No.10"
+	fmt.Println(rstring.Succ("a-9"))       // => "a-10"
+	fmt.Println(rstring.Succ(" "))         // => "!"
+
+	// マルチバイト文字
+	fmt.Println(rstring.Succ("あ"))   // => "ぃ"
+	fmt.Println(rstring.Succ("あz"))  // => "あaa"
+	fmt.Println(rstring.Succ("ｚ"))   // => "｛"
+	fmt.Println(rstring.Succ("Ｇo９")) // => "Ｇp９"
+}
+
+//---------------------------------------------------
+// "前"の文字列を取得する
+//---------------------------------------------------
+/*
+Rubyにはありませんが、succの逆です。rstring.Succ(rstring.Pred(s))がsになる文字列のうち
+一番短いものを返し、そのような文字列がなければ空文字列を返します。
+*/
+//import "github.com/ashitani/golangtips/pkg/tips/rstring"
+
+func string_Pred() {
+	fmt.Println(rstring.Pred("abce"))      // => "abcd"
+	fmt.Println(rstring.Pred("B00"))       // => "A99"
+	fmt.Println(rstring.Pred("10"))        // => "9"
+	fmt.Println(rstring.Pred("AAa"))       // => "Zz"
+	fmt.Println(rstring.Pred("2000aaa"))   // => "1999zzz"
+	fmt.Println(rstring.Pred("AAAA0000"))  // => "ZZZ9999"
+	fmt.Println(rstring.Pred("a-10"))      // => "a-9"
+	fmt.Println(rstring.Pred("<<koalb>>")) // => "<<koala>>"
+	fmt.Println(rstring.Pred("**+"))       // => "***"
+	fmt.Println(rstring.Pred("ａａ"))        // => "ａ｀"
+	fmt.Println(rstring.Pred("a") == "")   // => "true"
+}
+
+//---------------------------------------------------
+// 範囲内の文字列を順に取り出す
+//---------------------------------------------------
+/*
+RubyのString#uptoと同じく、fromからSucc()で進めながらtoまでの文字列を関数に渡します。
+3番目の引数はuptoのexclusiveで、trueならtoは含めません。
+
+- 1文字どうしなら文字コードの順に進めます("9"から"A"までなら記号も含む)。
+- 両方が数字だけなら数として数え、fromの桁数になるよう0で埋めます。
+- それ以外は、fromがtoより大きければ何もしません。toまで進むか、toより長くなったら止めます。
+*/
+//import "github.com/ashitani/golangtips/pkg/tips/rstring"
+
+func string_Upto() {
+	var ss []string
+	f := func(s string) { ss = append(ss, s) }
+
+	rstring.Upto("a", "e", false, f)
+	fmt.Println(ss) // => "[a b c d e]"
+
+	ss = nil
+	rstring.Upto("a", "e", true, f)
+	fmt.Println(ss) // => "[a b c d]"
+
+	ss = nil
+	rstring.Upto("9", "11", false, f)
+	fmt.Println(ss) // => "[9 10 11]"
+
+	ss = nil
+	rstring.Upto("07", "11", false, f)
+	fmt.Println(ss) // => "[07 08 09 10 11]"
+
+	ss = nil
+	rstring.Upto("a8", "b2", false, f)
+	fmt.Println(ss) // => "[a8 a9 b0 b1 b2]"
+
+	ss = nil
+	rstring.Upto("25", "5", false, f)
+	fmt.Println(len(ss)) // => "0"
 }
 
 //---------------------------------------------------
@@ -353,6 +556,7 @@ func succ(s string) string {
 //import     "crypto/md5"
 //import     "io"
 //import     "bufio"
+//import     "os"
 
 func string_Crypt() {
 	h := md5.New()
@@ -376,6 +580,10 @@ func string_Crypt() {
 //---------------------------------------------------
 // 文字列中で指定したパターンにマッチする部分を置換する
 //---------------------------------------------------
+/*
+strings.Replace()の最後の引数は置換する回数で、負の値なら全て置換します。
+Go 1.12からはstrings.ReplaceAll()もあります。
+*/
 //import "strings"
 
 func string_Replace() {
@@ -389,6 +597,9 @@ func string_Replace() {
 //---------------------------------------------------
 // 文字列中に含まれている任意文字列の位置を求める
 //---------------------------------------------------
+/*
+strings.Index()は見つからなければ-1を返します。位置はバイト単位です。
+*/
 //import "strings"
 
 func string_Find() {
@@ -405,6 +616,9 @@ func string_Find() {
 //---------------------------------------------------
 // 文字列の末端の改行を削除する
 //---------------------------------------------------
+/*
+strings.TrimRight()で末尾の改行を取り除きます。
+*/
 //import "strings"
 
 func string_Chomp() {
@@ -416,6 +630,9 @@ func string_Chomp() {
 //---------------------------------------------------
 // カンマ区切りの文字列を扱う
 //---------------------------------------------------
+/*
+strings.Split()で分割します。引用符を含むCSVは[encoding/csv](https://pkg.go.dev/encoding/csv)を使いましょう。
+*/
 //import "strings"
 
 func string_Split() {
@@ -430,6 +647,9 @@ func string_Split() {
 //---------------------------------------------------
 // 任意のパターンにマッチするものを全て抜き出す
 //---------------------------------------------------
+/*
+FindAllStringSubmatch()は、マッチごとに全体とカッコの部分を並べた配列を返します。
+*/
 // import "regexp"
 
 func string_FindAll() {
@@ -511,6 +731,126 @@ func KconvFromBuffer() {
 	fmt.Println(b.String())
 }
 
+//---------------------------------------------------
+// 漢字コードを判定する
+//---------------------------------------------------
+/*
+どの文字コードで来るかわからないデータは、pkg/tips/kconvで判定してから変換します。
+Shift_JIS、EUC-JP、ISO-2022-JP、UTF-8(BOMのあるものとないもの)を見分けます。
+
+`kconv.Guess()`はBOMとエスケープシーケンスを調べ、なければUTF-8、Shift_JIS、EUC-JPとして
+読んだときに、かなや漢字がどれだけ現れるかを1文字ずつ点を付けて選びます。
+`kconv.NewReader()`に`kconv.Auto`を渡すと、先頭を読んで判定しながらUTF-8で読み出せます。
+書き出す`kconv.NewWriter()`は、最後に`Close()`します。
+
+コマンドラインでは`golangtips nkf`が使えます。
+
+```
+golangtips nkf -g sjis.txt            # 判定だけ (Shift_JIS (CRLF) のように表示)
+golangtips nkf -w -Lu sjis.txt        # UTF-8、改行をLFにする
+golangtips nkf -S -e < sjis.txt       # Shift_JISとして読み、EUC-JPで書く
+```
+*/
+//import "bytes"
+//import "io"
+//import "github.com/ashitani/golangtips/pkg/tips/kconv"
+
+func string_KconvGuess() {
+	// "漢字です"
+	sjis := []byte{0x8a, 0xbf, 0x8e, 0x9a, 0x82, 0xc5, 0x82, 0xb7}
+	euc := []byte{0xb4, 0xc1, 0xbb, 0xfa, 0xa4, 0xc7, 0xa4, 0xb9}
+	jis := []byte("\x1b$B4A;z$G$9\x1b(B")
+	utf8bom := []byte("\xef\xbb\xbf漢字です")
+
+	fmt.Println(kconv.Guess(sjis))    // => "Shift_JIS"
+	fmt.Println(kconv.Guess(euc))     // => "EUC-JP"
+	fmt.Println(kconv.Guess(jis))     // => "ISO-2022-JP"
+	fmt.Println(kconv.Guess(utf8bom)) // => "UTF-8 (BOM)"
+
+	// 判定しながらUTF-8で読み出す
+	b := new(bytes.Buffer)
+	io.Copy(b, kconv.NewReader(bytes.NewReader(euc), kconv.Auto))
+	fmt.Println(b.String()) // => "漢字です"
+
+	// UTF-8をShift_JISで書き出す
+	out := new(bytes.Buffer)
+	w := kconv.NewWriter(out, kconv.ShiftJIS)
+	io.Copy(w, b)
+	w.Close()
+	fmt.Println(bytes.Equal(out.Bytes(), sjis)) // => "true"
+}
+
+//---------------------------------------------------
+// 全角と半角の英数字・記号を変換する
+//---------------------------------------------------
+/*
+全角の英数字・記号(！から～まで)と全角の空白を半角に、またはその逆にします。
+pkg/tips/kconvの`kconv.ToHankaku`と`kconv.ToZenkaku`は`transform.Transformer`なので、
+漢字コードの変換などと`transform.Chain()`でつなげられます。
+
+golang.org/x/text/widthの`width.Narrow`や`width.Widen`でも変換できますが、
+そちらはカタカナや「」なども変えてしまいます。ここでは英数字と記号だけを変えます。
+*/
+//import "golang.org/x/text/transform"
+//import "github.com/ashitani/golangtips/pkg/tips/kconv"
+
+func string_Zenkaku() {
+	s, _, _ := transform.String(kconv.ToHankaku, "ＡＢＣ　１２３！＠＃　カナ")
+	fmt.Println(s) // => "ABC 123!@# カナ"
+
+	s, _, _ = transform.String(kconv.ToZenkaku, "Go 1.4 (2014)")
+	fmt.Println(s) // => "Ｇｏ　１．４　（２０１４）"
+}
+
+//---------------------------------------------------
+// 半角カナと全角カナを変換する
+//---------------------------------------------------
+/*
+`kconv.ToZenkakuKana`で半角カナを全角にするときは、後ろの濁点・半濁点をまとめて1文字にします(ｶﾞ→ガ、ﾊﾟ→パ)。
+`kconv.ToHankakuKana`で全角を半角にするときは、濁点・半濁点を分けます。句読点やかぎかっこは全角のままです。
+
+濁点が次の読み込みに分かれていても正しく合成するよう、`transform.ErrShortSrc`で続きを待ちます。
+*/
+//import "golang.org/x/text/transform"
+//import "strings"
+//import "github.com/ashitani/golangtips/pkg/tips/kconv"
+
+func string_ZenkakuKana() {
+	s, _, _ := transform.String(kconv.ToZenkakuKana, "ｶﾞｷﾞｸﾞ ﾊﾟﾋﾟﾌﾟ ｳﾞｧｲｵﾘﾝ ｺﾝﾆﾁﾊ｡")
+	fmt.Println(s) // => "ガギグ パピプ ヴァイオリン コンニチハ。"
+
+	s, _, _ = transform.String(kconv.ToHankakuKana, "ガギグ パピプ ヴァイオリン コンニチハ。")
+	fmt.Println(s) // => "ｶﾞｷﾞｸﾞ ﾊﾟﾋﾟﾌﾟ ｳﾞｧｲｵﾘﾝ ｺﾝﾆﾁﾊ。"
+
+	// 1バイトずつ書き込んでも合成できる
+	var b strings.Builder
+	w := transform.NewWriter(&b, kconv.ToZenkakuKana)
+	for _, c := range []byte("ﾃﾞｰﾀ") {
+		w.Write([]byte{c})
+	}
+	w.Close()
+	fmt.Println(b.String()) // => "データ"
+}
+
+//---------------------------------------------------
+// ひらがなとカタカナを変換する
+//---------------------------------------------------
+/*
+ひらがな(ぁからゖ、ゝゞ)と、同じ音のカタカナは文字コードが0x60ずつ離れています。
+ヷやヺのようにひらがなのないカタカナと、半角カナはそのままにします。
+半角カナも変えたいときは、`transform.Chain(kconv.ToZenkakuKana, kconv.ToHiragana)`のようにつなげます。
+*/
+//import "golang.org/x/text/transform"
+//import "github.com/ashitani/golangtips/pkg/tips/kconv"
+
+func string_Katakana() {
+	s, _, _ := transform.String(kconv.ToKatakana, "ひらがなをかたかなに。ゔぁいおりん")
+	fmt.Println(s) // => "ヒラガナヲカタカナニ。ヴァイオリン"
+
+	s, _, _ = transform.String(kconv.ToHiragana, "カタカナヲヒラガナニ。ヾ")
+	fmt.Println(s) // => "かたかなをひらがなに。ゞ"
+}
+
 //---------------------------------------------------
 // マルチバイト文字の数を数える
 //---------------------------------------------------
@@ -527,6 +867,9 @@ func string_Count() {
 //---------------------------------------------------
 // マルチバイト文字列の最後の1文字を削除する
 //---------------------------------------------------
+/*
+[]runeに変換すると文字単位で扱えます。
+*/
 func string_ChopRune() {
 	s := "日本語"
 	sc := []rune(s)
@@ -536,34 +879,97 @@ func string_ChopRune() {
 //---------------------------------------------------
 // 文字列
 //---------------------------------------------------
-func Tips_string() {
-	string_Concat()             // 文字列を結合する
-	string_Repeat()             // 繰り返し文字列を生成する
-	string_UpperLower()         // 大文字・小文字に揃える
-	string_Exec()               // コマンドの実行結果を文字列に
-	string_HereDocument()       // 複数行の文字列を作成する
-	string_HereDocumentIndent() // ヒアドキュメントの終端文字列をインデントする
-	string_ExecMultiLine()      // 複数行のコマンドの実行結果を文字列に設定する
-	string_Extract()            // 部分文字列を取り出す
-	string_ReplacePart()        // 部分文字列を置き換える
-	string_Eval()               // 文字列中の式を評価し値を展開する
-	string_Each()               // 文字列を1文字ずつ処理する
-	string_Trim()               // 文字列の先頭と末尾の空白文字を削除する
-	string_ToI()                // 文字列を整数に変換する (to_i)
-	string_ToF()                // 文字列を浮動小数点に変換する (to_f)
-	string_ParseOct()           // 8進文字列を整数に変換する
-	string_ParseHex()           // 16進文字列を整数に変換する
-	string_AtoI()               // ASCII文字をコード値に（コード値をASCII文字に）変換する
-	string_Just()               // 文字列を中央寄せ・左詰・右詰する
-	string_Succ()               // "次"の文字列を取得する
-	string_Crypt()              // 文字列を暗号化する
-	string_Replace()            // 文字列中で指定したパターンにマッチする部分を置換する
-	string_Find()               // 文字列中に含まれている任意文字列の位置を求める
-	string_Chomp()              // 文字列の末端の改行を削除する
-	string_Split()              // カンマ区切りの文字列を扱う
-	string_FindAll()            // 任意のパターンにマッチするものを全て抜き出す
-	string_Kconv()              // 漢字コードを変換する
-	string_Count()              // マルチバイト文字の数を数える
-	string_ChopRune()           // マルチバイト文字列の最後の1文字を削除する
-
+//go:embed tips_string.go
+var source []byte
+
+func init() {
+	tips.Register("pkg/tips_string/tips_string.go", "文字列", source, map[string]func(){
+		"string_Concat":             string_Concat,
+		"string_Repeat":             string_Repeat,
+		"string_UpperLower":         string_UpperLower,
+		"string_ReplaceUpperLower":  string_ReplaceUpperLower,
+		"string_Exec":               string_Exec,
+		"string_HereDocument":       string_HereDocument,
+		"string_HereDocumentIndent": string_HereDocumentIndent,
+		"string_ExecMultiLine":      string_ExecMultiLine,
+		"string_Extract":            string_Extract,
+		"string_ReplacePart":        string_ReplacePart,
+		"string_Tr":                 string_Tr,
+		"string_Delete":             string_Delete,
+		"string_Eval":               string_Eval,
+		"string_Each":               string_Each,
+		"string_Trim":               string_Trim,
+		"string_ToI":                string_ToI,
+		"string_ToF":                string_ToF,
+		"string_ParseOct":           string_ParseOct,
+		"string_ParseHex":           string_ParseHex,
+		"string_AtoI":               string_AtoI,
+		"string_Just":               string_Just,
+		"string_JustWidth":          string_JustWidth,
+		"string_Succ":               string_Succ,
+		"string_Pred":               string_Pred,
+		"string_Upto":               string_Upto,
+		"string_Crypt":              string_Crypt,
+		"string_Replace":            string_Replace,
+		"string_Find":               string_Find,
+		"string_Chomp":              string_Chomp,
+		"string_Split":              string_Split,
+		"string_FindAll":            string_FindAll,
+		"string_Kconv":              string_Kconv,
+		"string_KconvGuess":         string_KconvGuess,
+		"string_Zenkaku":            string_Zenkaku,
+		"string_ZenkakuKana":        string_ZenkakuKana,
+		"string_Katakana":           string_Katakana,
+		"string_Count":              string_Count,
+		"string_ChopRune":           string_ChopRune,
+	})
+
+	// 端末なしで実行するときの入力
+	tips.SetInputs(map[string]string{
+		"string_Crypt": "line hogehoge\n",
+	})
+
+	// 逆引きRubyで対応するメソッド
+	tips.SetRuby(map[string][]string{
+		"string_Concat":            []string{"String#+", "String#<<"},
+		"string_Repeat":            []string{"String#*"},
+		"string_UpperLower":        []string{"String#upcase", "String#downcase", "String#capitalize"},
+		"string_ReplaceUpperLower": []string{"String#swapcase"},
+		"string_Exec":              []string{"Kernel#`"},
+		"string_ExecMultiLine":     []string{"Kernel#`"},
+		"string_Extract":           []string{"String#[]", "String#slice"},
+		"string_ReplacePart":       []string{"String#[]="},
+		"string_Tr":                []string{"String#tr", "String#tr_s"},
+		"string_Delete":            []string{"String#delete", "String#squeeze", "String#count"},
+		"string_Each":              []string{"String#each_char", "String#each_byte"},
+		"string_Trim":              []string{"String#strip", "String#lstrip", "String#rstrip"},
+		"string_ToI":               []string{"String#to_i"},
+		"string_ToF":               []string{"String#to_f"},
+		"string_ParseOct":          []string{"String#oct"},
+		"string_ParseHex":          []string{"String#hex"},
+		"string_AtoI":              []string{"String#ord", "Integer#chr"},
+		"string_Just":              []string{"String#center", "String#ljust", "String#rjust"},
+		"string_JustWidth":         []string{"String#center", "String#ljust", "String#rjust"},
+		"string_Succ":              []string{"String#succ", "String#next"},
+		"string_Upto":              []string{"String#upto"},
+		"string_Crypt":             []string{"String#crypt"},
+		"string_Replace":           []string{"String#sub", "String#gsub"},
+		"string_Find":              []string{"String#index", "String#rindex"},
+		"string_Chomp":             []string{"String#chomp"},
+		"string_Split":             []string{"String#split"},
+		"string_FindAll":           []string{"String#scan"},
+		"string_Kconv":             []string{"Kconv.kconv", "String#encode"},
+		"string_KconvGuess":        []string{"Kconv.guess", "NKF.guess", "NKF.nkf"},
+		"string_Zenkaku":           []string{"NKF.nkf"},
+		"string_ZenkakuKana":       []string{"NKF.nkf"},
+		"string_Count":             []string{"String#length", "String#size"},
+		"string_ChopRune":          []string{"String#chop"},
+	})
+
+	// 中心になる補助関数のベンチマーク
+	tips.SetBenchmarks(map[string]func(){
+		"string_Succ": func() { rstring.Succ("A099") },
+		"string_Pred": func() { rstring.Pred("A100") },
+		"string_Upto": func() { rstring.Upto("a8", "d2", false, func(string) {}) },
+	})
 }
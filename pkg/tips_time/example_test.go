@@ -0,0 +1,110 @@
+// Code generated by make_examples; DO NOT EDIT.
+
+package tips_time
+
+import "time"
+
+// 次のTipsはExampleにできないので生成していません。
+//
+//   time_Now: 現在時刻を使う (time.Now)
+//   time_Format: 現在時刻を使う (time.Now)
+//   time_ToString: 現在時刻を使う (time.Now)
+//   time_JapaneseWeekday: 現在時刻を使う (time.Now)
+//   time_Unix: 現在時刻を使う (time.Now)
+//   time_Date: 現在時刻を使う (time.Now)
+//   time_DateString: 現在時刻を使う (time.Now)
+
+func init() {
+	time.Local = time.FixedZone("JST", 9*60*60)
+}
+
+// 時刻オブジェクトを作成する
+func Example_make() {
+	time_Make()
+	// Output:
+	// 2001-05-20 23:59:59 +0000 UTC
+	// 2001-05-20 23:59:59 +0900 JST
+}
+
+// 時刻に任意の時間を加減する
+func Example_incDec() {
+	time_IncDec()
+	// Output:
+	// 2001-05-21 00:00:00 +0900 JST
+	// 2001-01-01 00:00:00 +0900 JST
+}
+
+// 2つの時刻の差を求める
+func Example_duration() {
+	time_Duration()
+	// Output:
+	// 60h30m0s
+	// 2 days + 12 hours + 30 minutes + 0 seconds
+}
+
+// 日付オブジェクトを作成する
+func Example_makeDate() {
+	time_MakeDate()
+	// Output:
+	// 2001-05-31 00:00:00 +0900 JST
+}
+
+// 指定の日付が存在するかどうか調べる
+func Example_exist() {
+	time_Exist()
+	// Output:
+	// 2451940
+	// 2001-1-32 is not exist
+}
+
+// ユリウス日から日付オブジェクトを作成する
+func Example_fromJulian() {
+	time_FromJulian()
+	// Output:
+	// 2.451940124997685e+06
+	// 2001-01-31 00:00:00.000014592 +0900 JST
+}
+
+// 何日後、何日前の日付を求める
+func Example_incDecDay() {
+	time_IncDecDay()
+	// Output:
+	// 2001-06-01 00:00:00 +0900 JST
+	// 2000-12-31 00:00:00 +0900 JST
+}
+
+// 何ヶ月後、何ヶ月前の日付を求める
+func Example_incDecMonth() {
+	time_IncDecMonth()
+	// Output:
+	// 2001-03-03 00:00:00 +0900 JST
+	// 2001-02-28 00:00:00 +0900 JST
+	// 2001-05-01 00:00:00 +0900 JST
+	// 2001-04-30 00:00:00 +0900 JST
+}
+
+// うるう年かどうか判定する
+func Example_leapYear() {
+	time_LeapYear()
+	// Output:
+	// true
+	// false
+}
+
+// 日付オブジェクトの年月日・曜日を個別に扱う
+func Example_decompose() {
+	time_Decompose()
+	// Output:
+	// 2001
+	// January
+	// 31
+	// Wednesday
+}
+
+// 文字列の日付を日付オブジェクトに変換する
+func Example_parse() {
+	time_Parse()
+	// Output:
+	// 2001-05-24 22:56:30 +0900 JST
+	// 2003-04-18 00:00:00 +0000 UTC
+}
@@ -5,17 +5,23 @@
 package tips_time
 
 import (
+	_ "embed"
 	"fmt"
 	"time"
+
+	"github.com/ashitani/golangtips/pkg/tips"
 )
 
 //---------------------------------------------------
 // 現在の時刻を取得する
 //---------------------------------------------------
+/*
+time.Now()で現在の時刻を取得し、Year()やMonth()などで要素を取り出します。
+*/
 //import "time"
 
 func time_Now() {
-	t := time.Now()
+	t := tips.Clock.Now()
 	fmt.Println(t)           // => "2015-05-05 07:23:30.757800829 +0900 JST"
 	fmt.Println(t.Year())    // => "2015"
 	fmt.Println(t.Month())   // => "May"
@@ -55,24 +61,27 @@ time.FormatはrubyのTime::strftimeと違い、
 //import "time"
 
 func time_Format() {
-	t := time.Now()
+	t := tips.Clock.Now()
 	const layout = "Now, Monday Jan 02 15:04:05 JST 2006"
-	fmt.Println(t.Format(layout)) // => "Now, Tuesday May 05 07:53:54 JST 2015"
+	fmt.Println(t.Format(layout)) // => "Now, Tuesday May 05 07:23:30 JST 2015"
 	const layout2 = "2006-01-02 15:04:05"
-	fmt.Println(t.Format(layout2)) // => "2015-05-05 07:53:54"
+	fmt.Println(t.Format(layout2)) // => "2015-05-05 07:23:30"
 
 }
 
 //---------------------------------------------------
 // 時刻オブジェクトを文字列に変換する
 //---------------------------------------------------
+/*
+String()で文字列になります。好きな形にするには[日付オブジェクトを文字列に変換する](#time_DateString)のFormat()を使います。
+*/
 //import "time"
 
 func time_ToString() {
-	t := time.Now()
+	t := tips.Clock.Now()
 	s := ""
 	s = t.String()
-	fmt.Println(s) // => "2015-05-05 08:05:15.828452891 +0900 JST"
+	fmt.Println(s) // => "2015-05-05 07:23:30.757800829 +0900 JST"
 }
 
 //---------------------------------------------------
@@ -105,6 +114,9 @@ func time_IncDec() {
 //---------------------------------------------------
 // 2つの時刻の差を求める
 //---------------------------------------------------
+/*
+Sub()でtime.Durationが得られます。日数のメソッドはないので、時間から計算します。
+*/
 //import "time"
 
 func time_Duration() {
@@ -134,7 +146,7 @@ time.Weekday は普段はintだけどPrintln等でString()メソッドを
 func time_JapaneseWeekday() {
 	wdays := [...]string{"日", "月", "火", "水", "木", "金", "土"}
 
-	t := time.Now()
+	t := tips.Clock.Now()
 	fmt.Println(t.Weekday())        // =>"Tuesday"
 	fmt.Println(wdays[t.Weekday()]) // =>"火"
 }
@@ -142,11 +154,14 @@ func time_JapaneseWeekday() {
 //---------------------------------------------------
 // UNIXタイムをTimeオブジェクトに変換する
 //---------------------------------------------------
+/*
+time.Unix()でUNIXタイムからTimeを作り、逆はUnix()です。
+*/
 //import "time"
 
 func time_Unix() {
 	fmt.Println(time.Unix(1267867237, 0)) // => "2010-03-06 18:20:37 +0900 JST"
-	fmt.Println(time.Now().Unix())        // => "1430783059"
+	fmt.Println(tips.Clock.Now().Unix())        // => "1430778210"
 }
 
 //---------------------------------------------------
@@ -156,7 +171,7 @@ func time_Unix() {
 //import "time"
 
 func time_Date() {
-	day := time.Now()
+	day := tips.Clock.Now()
 	const layout = "2006-01-02"
 	fmt.Println(day.Format(layout)) // => "2015-05-05"
 }
@@ -164,17 +179,23 @@ func time_Date() {
 //---------------------------------------------------
 // 日付オブジェクトを文字列に変換する
 //---------------------------------------------------
+/*
+Format()に2006-01-02 15:04:05の形でレイアウトを渡します。
+*/
 //import "time"
 
 func time_DateString() {
-	day := time.Now()
+	day := tips.Clock.Now()
 	const layout = "2006-01-02"
 	fmt.Println(day.Format(layout)) // => "2015-05-05"
 }
 
 //---------------------------------------------------
 // 日付オブジェクトを作成する
-///---------------------------------------------------
+//---------------------------------------------------
+/*
+time.Date()に年月日時分秒ナノ秒とタイムゾーンを渡します。
+*/
 //import "time"
 
 func time_MakeDate() {
@@ -193,14 +214,14 @@ func time_MakeDate() {
 //import "time"
 
 func time_Exist() {
-	jd, err := isExist(2001, 1, 31)
+	jd, err := isExistDate(2001, 1, 31)
 	if err != nil {
 		fmt.Println(err)
 	} else {
 		fmt.Println(int(jd))
 	}
 	// => "2451940"
-	jd, err = isExist(2001, 1, 32)
+	jd, err = isExistDate(2001, 1, 32)
 	if err != nil {
 		fmt.Println(err)
 	} else {
@@ -212,7 +233,7 @@ func time_Exist() {
 // 指定の日付が存在するかどうか調べる。
 // 存在しない日付を指定してもtime.Date()はよきに計らってくれるので、
 // 指定した日付と違うtime.Timeが返ってくれば指定した日付が間違ってると判定。
-func isExist(year, month, day int) (float64, error) {
+func isExistDate(year, month, day int) (float64, error) {
 	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
 	if date.Year() == year && date.Month() == time.Month(month) && date.Day() == day {
 		return Julian(date), nil
@@ -321,6 +342,9 @@ func getLastDay(year, month int) int {
 //---------------------------------------------------
 // うるう年かどうか判定する
 //---------------------------------------------------
+/*
+標準の関数はないので、400、100、4で割り切れるかを順に調べます。
+*/
 func time_LeapYear() {
 	fmt.Println(isLeapYear(2000)) // => "true"
 	fmt.Println(isLeapYear(2001)) // => "false"
@@ -343,6 +367,9 @@ func isLeapYear(year int) bool {
 //---------------------------------------------------
 // 日付オブジェクトの年月日・曜日を個別に扱う
 //---------------------------------------------------
+/*
+Year()、Month()、Day()、Weekday()で取り出せます。
+*/
 //import "time"
 
 func time_Decompose() {
@@ -380,24 +407,50 @@ func time_Parse() {
 //---------------------------------------------------
 // 日付と時刻
 //---------------------------------------------------
-func Tips_time() {
-	time_Now()             // 現在の時刻を取得する
-	time_Make()            // 時刻オブジェクトを作成する
-	time_Format()          // 時刻を任意のフォーマットで扱う
-	time_ToString()        // 時刻オブジェクトを文字列に変換する
-	time_IncDec()          // 時刻に任意の時間を加減する
-	time_Duration()        // 2つの時刻の差を求める
-	time_JapaneseWeekday() // 時刻中の曜日を日本語に変換する
-	time_Unix()            // UNIXタイムをTimeオブジェクトに変換する
-	time_Date()            // 現在の日付を求める
-	time_DateString()      // 日付オブジェクトを文字列に変換する
-	time_MakeDate()        // 日付オブジェクトを作成する
-	time_Exist()           // 指定の日付が存在するかどうか調べる
-	time_FromJulian()      // ユリウス日から日付オブジェクトを作成する
-	time_IncDecDay()       // 何日後、何日前の日付を求める
-	time_IncDecMonth()     // 何ヶ月後、何ヶ月前の日付を求める
-	time_LeapYear()        // うるう年かどうか判定する
-	time_Decompose()       // 日付オブジェクトの年月日・曜日を個別に扱う
-	time_Parse()           // 文字列の日付を日付オブジェクトに変換する
-
+//go:embed tips_time.go
+var source []byte
+
+func init() {
+	tips.Register("pkg/tips_time/tips_time.go", "日付と時刻", source, map[string]func(){
+		"time_Now":             time_Now,
+		"time_Make":            time_Make,
+		"time_Format":          time_Format,
+		"time_ToString":        time_ToString,
+		"time_IncDec":          time_IncDec,
+		"time_Duration":        time_Duration,
+		"time_JapaneseWeekday": time_JapaneseWeekday,
+		"time_Unix":            time_Unix,
+		"time_Date":            time_Date,
+		"time_DateString":      time_DateString,
+		"time_MakeDate":        time_MakeDate,
+		"time_Exist":           time_Exist,
+		"time_FromJulian":      time_FromJulian,
+		"time_IncDecDay":       time_IncDecDay,
+		"time_IncDecMonth":     time_IncDecMonth,
+		"time_LeapYear":        time_LeapYear,
+		"time_Decompose":       time_Decompose,
+		"time_Parse":           time_Parse,
+	})
+
+	// 逆引きRubyで対応するメソッド
+	tips.SetRuby(map[string][]string{
+		"time_Now":             []string{"Time.now"},
+		"time_Make":            []string{"Time.local", "Time.mktime", "Time.gm"},
+		"time_Format":          []string{"Time#strftime"},
+		"time_ToString":        []string{"Time#to_s"},
+		"time_IncDec":          []string{"Time#+", "Time#-"},
+		"time_Duration":        []string{"Time#-"},
+		"time_JapaneseWeekday": []string{"Time#wday"},
+		"time_Unix":            []string{"Time.at"},
+		"time_Date":            []string{"Date.today"},
+		"time_DateString":      []string{"Date#to_s", "Date#strftime"},
+		"time_MakeDate":        []string{"Date.new"},
+		"time_Exist":           []string{"Date.valid_date?"},
+		"time_FromJulian":      []string{"Date.jd"},
+		"time_IncDecDay":       []string{"Date#+", "Date#-"},
+		"time_IncDecMonth":     []string{"Date#>>", "Date#<<"},
+		"time_LeapYear":        []string{"Date.leap?", "Date#leap?"},
+		"time_Decompose":       []string{"Date#year", "Date#month", "Date#day", "Date#wday"},
+		"time_Parse":           []string{"Date.parse"},
+	})
 }
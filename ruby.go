@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+)
+
+var cmdRuby = &command{
+	name:  "ruby",
+	usage: "ruby [method...]",
+	short: "Rubyのメソッド名 (例: Array#assoc) からTipsを探す",
+	run:   runRuby,
+}
+
+func runRuby(args []string) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+
+	// 引数がなければ対応表を表示する
+	if len(args) == 0 {
+		for _, m := range tips.RubyMethods() {
+			var ids []string
+			for _, t := range tips.LookupRuby(m) {
+				ids = append(ids, t.ID)
+			}
+			fmt.Fprintf(w, "%s\t%s\n", m, strings.Join(ids, " "))
+		}
+		return w.Flush()
+	}
+
+	for _, m := range args {
+		ts := tips.LookupRuby(m)
+		if len(ts) == 0 {
+			w.Flush()
+			return fmt.Errorf("no tip for %q", m)
+		}
+		for _, t := range ts {
+			printTip(w, t)
+		}
+	}
+	return w.Flush()
+}
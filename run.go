@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/version"
+	"os"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+	"github.com/ashitani/golangtips/pkg/tips/input"
+)
+
+var cmdRun = &command{
+	name:  "run",
+	usage: "run [-auto] [-input file] [-go version] <id|category>...",
+	short: "指定したTipsまたはカテゴリを実行する",
+	run:   runRun,
+}
+
+func runRun(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	auto := fs.Bool("auto", false, "キー入力や行入力にTipsごとに用意したスクリプトを使い、端末から読まない")
+	script := fs.String("input", "", "キー入力や行入力を端末の代わりにこのスクリプトから読む")
+	goVersion := fs.String("go", "", "新しい書き方の版があるTipsは、このバージョンのGoで使える版を実行する (例: go1.4)。省略すると実行中のGo")
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		return errors.New("usage: golangtips run [-auto] [-input file] [-go version] <id|category>...")
+	}
+	if *goVersion != "" && !version.IsValid(*goVersion) {
+		return fmt.Errorf("-go %s: not a Go version such as go1.21", *goVersion)
+	}
+
+	// 先に全部解決してから実行する
+	ts, err := selectTips(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	if *script != "" {
+		f, err := os.Open(*script)
+		if err != nil {
+			return err
+		}
+		s, err := input.Parse(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %v", *script, err)
+		}
+		restore := input.Feed(s)
+		defer restore()
+	}
+
+	for _, t := range ts {
+		if *goVersion == "" {
+			t = t.ForToolchain()
+		} else {
+			t = t.Variant(*goVersion)
+		}
+		if len(ts) > 1 {
+			fmt.Printf("=== %s: %s\n", t.ID, t.Title)
+		}
+		run := t.Run
+		if *auto {
+			run = t.RunUnattended
+		}
+		if err := run(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// selectTips は引数のIDかカテゴリに当たるTipsを返します。引数がなければ全てです。
+func selectTips(args []string) ([]*tips.Tip, error) {
+	if len(args) == 0 {
+		for _, c := range tips.Categories() {
+			args = append(args, c.Name)
+		}
+	}
+
+	var ts []*tips.Tip
+	for _, arg := range args {
+		if t, ok := tips.Lookup(arg); ok {
+			ts = append(ts, t)
+		} else if c, ok := tips.LookupCategory(arg); ok {
+			for _, t := range c.Tips {
+				if t.Func != nil {
+					ts = append(ts, t)
+				}
+			}
+		} else {
+			return nil, fmt.Errorf("unknown tip or category %q", arg)
+		}
+	}
+	return ts, nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+)
+
+// 実行したマシンの様子をそのまま表示するので、毎回同じにはならないTips
+var hostDependent = map[string]string{
+	"goroutine_ListGoroutines": "goroutineのスタックを表示する",
+	"file_Stat":                "サンドボックスに置くたびに変わるinodeや時刻を表示する",
+}
+
+// 元のTipsと同じ出力にならない版
+var differentOutput = map[string]string{
+	"slice_Choice_go1_20": "slice_Choiceは選ぶたびに現在時刻でシードし直すので、時刻を止めると同じ要素が続く",
+}
+
+// サンドボックスの一時ディレクトリは毎回名前が変わる
+var sandboxDir = regexp.MustCompile(regexp.QuoteMeta(filepath.Join(os.TempDir(), "golangtips")) + `[0-9]+`)
+
+// GOLANGTIPS_NOW と GOLANGTIPS_SEED を決めれば、どのTipsも何度実行しても同じ出力になること
+func TestRunReproducible(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and runs every tip twice")
+	}
+	t.Setenv("GOLANGTIPS_NOW", "2015-05-05T07:23:30.757800829+09:00")
+	t.Setenv("GOLANGTIPS_SEED", "1")
+
+	for _, tip := range tips.WithVariants(tips.All()) {
+		if tip.Func == nil {
+			continue
+		}
+		tip := tip
+		t.Run(tip.ID, func(t *testing.T) {
+			if reason, ok := hostDependent[tip.ID]; ok {
+				t.Skip(reason)
+			}
+			for _, e := range tip.Effects() {
+				if e.Kind == "exec" {
+					t.Skip(e.Reason())
+				}
+			}
+			first, second := runOutput(t, tip), runOutput(t, tip)
+			if first != second {
+				t.Errorf("output changed between runs:\n%s\n---\n%s", first, second)
+			}
+		})
+	}
+}
+
+// 新しい書き方の版は、元のTipsと同じ出力になること
+func TestVariantsMatchBase(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and runs every variant")
+	}
+	t.Setenv("GOLANGTIPS_NOW", "2015-05-05T07:23:30.757800829+09:00")
+	t.Setenv("GOLANGTIPS_SEED", "1")
+
+	for _, base := range tips.All() {
+		for _, v := range base.Variants {
+			base, v := base, v
+			t.Run(v.ID, func(t *testing.T) {
+				if reason, ok := differentOutput[v.ID]; ok {
+					t.Skip(reason)
+				}
+				want, got := runOutput(t, base), runOutput(t, v)
+				if got != want {
+					t.Errorf("%s prints\n%s\nbut %s prints\n%s", v.ID, got, base.ID, want)
+				}
+			})
+		}
+	}
+}
+
+// runOutput はTipsを実行した出力を、サンドボックスの名前を $SANDBOX にして返します。
+func runOutput(t *testing.T, tip *tips.Tip) string {
+	t.Helper()
+	var runErr error
+	out, err := tips.Capture(func() { runErr = tip.RunUnattended() })
+	if err == nil {
+		err = runErr
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sandboxDir.ReplaceAllString(out, "$$SANDBOX")
+}
+
+// -go にバージョンでないものを渡したら、実行せずにエラーにすること
+func TestRunRejectsBadGoVersion(t *testing.T) {
+	for _, v := range []string{"bogus", "1.21", "go"} {
+		if err := runRun([]string{"-go", v, "slice_Reverse"}); err == nil {
+			t.Errorf("run -go %s: no error", v)
+		}
+	}
+}
+
+// /etc を使うTipsは、サンドボックスの etc を /etc として表示すること
+func TestRunRemapsEtc(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and runs a tip")
+	}
+	tip, ok := tips.Lookup("dir_ShowFullPath")
+	if !ok {
+		t.Fatal("dir_ShowFullPath is not registered")
+	}
+	want := `/etc/
+/etc/group
+/etc/hostname
+/etc/hosts
+/etc/network
+/etc/network/interfaces
+/etc/passwd
+/etc/shells
+/etc/ssh
+/etc/ssh/ssh_config
+/etc/ssh/sshd_config
+`
+	if got := runOutput(t, tip); got != want {
+		t.Errorf("dir_ShowFullPath prints\n%s\nwant\n%s", got, want)
+	}
+}
+
+// 何も指定しなければ、全ての注記が出力と合うこと
+func TestVerify(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs every tip with annotations")
+	}
+	t.Setenv("GOLANGTIPS_NOW", "")
+	t.Setenv("GOLANGTIPS_SEED", "")
+	if err := runVerify(nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// GOLANGTIPS_NOW のオフセットで表示し、実行したマシンのタイムゾーンによらないこと
+func TestRunFixedZone(t *testing.T) {
+	t.Setenv("GOLANGTIPS_NOW", "2015-05-05T07:23:30.757800829+09:00")
+	t.Setenv("GOLANGTIPS_SEED", "")
+	local := time.Local
+	time.Local = time.UTC // TZ=UTC のマシン
+	defer func() { time.Local = local }()
+
+	tip, ok := tips.Lookup("time_Now")
+	if !ok {
+		t.Fatal("time_Now is not registered")
+	}
+	out := runOutput(t, tip)
+	if want := "2015-05-05 07:23:30.757800829 +0900"; !strings.HasPrefix(out, want) {
+		t.Errorf("time_Now prints\n%s\nwant it to start with %q", out, want)
+	}
+	if time.Local != time.UTC {
+		t.Errorf("time.Local is %v after the run, want UTC", time.Local)
+	}
+}
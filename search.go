@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+)
+
+var cmdSearch = &command{
+	name:  "search",
+	usage: "search <text>...",
+	short: "IDとタイトル、説明を検索する",
+	run:   runSearch,
+}
+
+func runSearch(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: golangtips search <text>...")
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	for _, t := range tips.All() {
+		if t.ID != "" && matchAll(t, args) {
+			printTip(w, t)
+		}
+	}
+	return w.Flush()
+}
+
+// 全ての語がID・タイトル・説明のどこかに含まれていればtrue。英字の大小は区別しない。
+func matchAll(t *tips.Tip, words []string) bool {
+	text := strings.ToLower(t.ID + "\n" + t.Title + "\n" + t.Description)
+	for _, w := range words {
+		if !strings.Contains(text, strings.ToLower(w)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+)
+
+var cmdServe = &command{
+	name:  "serve",
+	usage: "serve [-addr host:port] [-dir doc/html] [-timeout d]",
+	short: "ドキュメントをlocalhostで表示し、ページからTipsを実行する",
+	run:   runServe,
+}
+
+// ページに足す Run ボタンなど
+//
+//go:embed serve.js
+var serveJS []byte
+
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8080", "待ち受けるアドレス。コードを実行するので外には公開しないこと")
+	dir := fs.String("dir", filepath.Join("doc", "html"), "make_docで生成したhtmlの場所")
+	timeout := fs.Duration("timeout", 10*time.Second, "Run editedでビルドと実行それぞれにかけてよい時間")
+	fs.Parse(args)
+
+	if _, err := os.Stat(filepath.Join(*dir, "index.html")); err != nil {
+		return fmt.Errorf("%v (先に doc フォルダで go run ../cmd/make_doc を実行してください)", err)
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+
+	s := &server{dir: *dir, self: self, timeout: *timeout, port: port}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_serve/serve.js", s.script)
+	mux.HandleFunc("/_serve/run", s.run)
+	mux.HandleFunc("/_serve/run-edited", s.runEdited)
+	mux.HandleFunc("/", s.page)
+
+	log.Printf("http://%s/ で表示しています", *addr)
+	return http.Serve(ln, mux)
+}
+
+type server struct {
+	dir     string
+	self    string // Tipsは自分自身を golangtips run -auto で実行する
+	timeout time.Duration
+	port    string // 待ち受けているポート。Hostヘッダーと比べる
+}
+
+// page はhtmlにserve.jsを読み込むタグを足して返します。それ以外のファイルはそのままです。
+func (s *server) page(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Path
+	if strings.HasSuffix(name, "/") {
+		name += "index.html"
+	}
+	if !strings.HasSuffix(name, ".html") {
+		http.FileServer(http.Dir(s.dir)).ServeHTTP(w, r)
+		return
+	}
+	b, err := ioutil.ReadFile(filepath.Join(s.dir, filepath.FromSlash(filepath.Clean("/"+name))))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	tag := []byte(`<script src="/_serve/serve.js"></script>` + "\n")
+	if i := bytes.LastIndex(b, []byte("</body>")); i >= 0 {
+		b = append(b[:i:i], append(tag, b[i:]...)...)
+	} else {
+		b = append(b, tag...)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(b)
+}
+
+func (s *server) script(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+	w.Write(serveJS)
+}
+
+// run はTipsをサンドボックスの中で実行し、出力を少しずつ返します。
+// 入力を待つTipsには用意したスクリプトを流し込みます。
+func (s *server) run(w http.ResponseWriter, r *http.Request) {
+	if !s.allowed(w, r) {
+		return
+	}
+	id := r.FormValue("id")
+	if _, ok := tips.Lookup(id); !ok {
+		http.Error(w, fmt.Sprintf("unknown tip %q", id), http.StatusNotFound)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+	out := newFlushWriter(w)
+	s.exec(ctx, out, exec.CommandContext(ctx, s.self, "run", "-auto", id))
+}
+
+// runEdited は送られてきたコードを一時的なモジュールでビルドして実行し、出力を少しずつ返します。
+//
+// モジュールはサンドボックスに作るので、test.txt などのファイルはTipsと同じく使えます。
+// ネットワークには出ず、Goのモジュールキャッシュにあるパッケージだけを使います。
+func (s *server) runEdited(w http.ResponseWriter, r *http.Request) {
+	if !s.allowed(w, r) {
+		return
+	}
+	src, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sb, err := tips.NewSandbox()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer sb.Close()
+	files := map[string]string{
+		"main.go": string(src),
+		"go.mod":  "module play\n",
+	}
+	for name, body := range files {
+		if err := ioutil.WriteFile(filepath.Join(sb.Dir, name), []byte(body), 0666); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	out := newFlushWriter(w)
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+	build := exec.CommandContext(ctx, "go", "build", "-o", "play.exe", ".")
+	build.Dir = sb.Dir
+	build.Env = tips.OfflineEnv()
+	if !s.exec(ctx, out, build) {
+		return
+	}
+
+	ctx, cancel = context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+	prog := exec.CommandContext(ctx, filepath.Join(sb.Dir, "play.exe"))
+	prog.Dir = sb.Dir
+	s.exec(ctx, out, prog)
+}
+
+// exec は cmd を実行し、標準出力と標準エラーを out に書きます。
+// 失敗や時間切れはその旨を out の最後に書いて false を返します。
+func (s *server) exec(ctx context.Context, out io.Writer, cmd *exec.Cmd) bool {
+	cmd.Stdout = out
+	cmd.Stderr = out
+	err := cmd.Run()
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		fmt.Fprintf(out, "\n%v で打ち切りました\n", s.timeout)
+	case err != nil:
+		fmt.Fprintf(out, "\n%v\n", err)
+	default:
+		return true
+	}
+	return false
+}
+
+// allowed は実行の要求を受け付けてよいか確かめます。
+//
+// 他のサイトのページから実行させられないように、POSTで同じオリジンからのものだけ受け付けます。
+// DNSリバインディングで他の名前からlocalhostに向けられたときのために、Hostも
+// localhost, 127.0.0.1, [::1] と待ち受けているポートの組み合わせに限ります。
+func (s *server) allowed(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST only", http.StatusMethodNotAllowed)
+		return false
+	}
+	if !s.loopback(r.Host) {
+		http.Error(w, fmt.Sprintf("host %q is not localhost", r.Host), http.StatusForbidden)
+		return false
+	}
+	u, err := url.Parse(r.Header.Get("Origin"))
+	if err != nil || u.Scheme != "http" || u.Host != r.Host {
+		http.Error(w, "cross-origin request", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// loopback は host が localhost, 127.0.0.1, [::1] のどれかと待ち受けているポートか確かめます。
+func (s *server) loopback(host string) bool {
+	name, port, err := net.SplitHostPort(host)
+	if err != nil || port != s.port {
+		return false
+	}
+	switch name {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	}
+	return false
+}
+
+// flushWriter は書くたびにクライアントへ送ります。
+type flushWriter struct {
+	w http.ResponseWriter
+	f http.Flusher
+}
+
+func newFlushWriter(w http.ResponseWriter) *flushWriter {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	f, _ := w.(http.Flusher)
+	return &flushWriter{w, f}
+}
+
+func (fw *flushWriter) Write(b []byte) (int, error) {
+	n, err := fw.w.Write(b)
+	if fw.f != nil {
+		fw.f.Flush()
+	}
+	return n, err
+}
@@ -0,0 +1,82 @@
+// golangtips serve で表示したときだけ読み込まれるスクリプト
+//
+// 各Tipsのコードに Run / Edit / Run edited のボタンを付け、
+// 実行結果をサーバーから少しずつ受け取ってコードの下に表示します。
+(function() {
+    // コードのブロックがどのTipsのものか調べる
+    function tipID(pre) {
+        var v = pre.closest(".variant");
+        if (v) {
+            var i = Array.prototype.indexOf.call(v.parentNode.querySelectorAll(".variant"), v);
+            var input = v.parentNode.querySelectorAll("input[type=radio]")[i];
+            return input ? input.id.replace(/^tab_/, "") : null;
+        }
+        for (var e = pre.previousElementSibling; e; e = e.previousElementSibling) {
+            if (e.tagName == "H2") {
+                var a = e.querySelector("a[name]");
+                return a ? a.getAttribute("name") : null;
+            }
+        }
+        return null;
+    }
+
+    function stream(url, body, out) {
+        out.textContent = "";
+        out.style.display = "block";
+        fetch(url, { method: "POST", body: body }).then(function(res) {
+            var reader = res.body.getReader();
+            var decoder = new TextDecoder();
+            function read() {
+                return reader.read().then(function(r) {
+                    if (r.done) {
+                        out.textContent += decoder.decode();
+                        return;
+                    }
+                    out.textContent += decoder.decode(r.value, { stream: true });
+                    return read();
+                });
+            }
+            return read();
+        }).catch(function(err) {
+            out.textContent += "\n" + err;
+        });
+    }
+
+    function button(label, onclick) {
+        var b = document.createElement("button");
+        b.textContent = label;
+        b.addEventListener("click", onclick);
+        return b;
+    }
+
+    document.querySelectorAll("pre > code.language-golang").forEach(function(code) {
+        var pre = code.parentNode;
+        var id = tipID(pre);
+        if (!id) return;
+
+        var bar = document.createElement("div");
+        bar.className = "run";
+        var out = document.createElement("pre");
+        out.className = "output";
+        out.style.display = "none";
+
+        var runEdited = button("Run edited", function() {
+            stream("/_serve/run-edited", code.textContent, out);
+        });
+        runEdited.disabled = true;
+
+        bar.appendChild(button("Run", function() {
+            stream("/_serve/run?id=" + encodeURIComponent(id), "", out);
+        }));
+        bar.appendChild(button("Edit", function() {
+            code.contentEditable = "plaintext-only";
+            code.spellcheck = false;
+            code.focus();
+            runEdited.disabled = false;
+        }));
+        bar.appendChild(runEdited);
+
+        pre.parentNode.insertBefore(bar, pre.nextSibling);
+        pre.parentNode.insertBefore(out, bar.nextSibling);
+    });
+})();
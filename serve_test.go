@@ -0,0 +1,38 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServeAllowed(t *testing.T) {
+	s := &server{port: "8080"}
+	tests := []struct {
+		method, host, origin string
+		want                 int
+	}{
+		{"POST", "localhost:8080", "http://localhost:8080", http.StatusOK},
+		{"POST", "127.0.0.1:8080", "http://127.0.0.1:8080", http.StatusOK},
+		{"POST", "[::1]:8080", "http://[::1]:8080", http.StatusOK},
+		{"GET", "localhost:8080", "http://localhost:8080", http.StatusMethodNotAllowed},
+		{"POST", "localhost:8080", "", http.StatusForbidden},                            // Originがない
+		{"POST", "localhost:8080", "http://evil.example:8080", http.StatusForbidden},    // 別のオリジン
+		{"POST", "localhost:8080", "https://localhost:8080", http.StatusForbidden},      // 別のスキーム
+		{"POST", "evil.example:8080", "http://evil.example:8080", http.StatusForbidden}, // DNSリバインディング
+		{"POST", "localhost:9090", "http://localhost:9090", http.StatusForbidden},       // 別のポート
+		{"POST", "localhost", "http://localhost", http.StatusForbidden},                 // ポートがない
+		{"POST", "192.168.0.1:8080", "http://192.168.0.1:8080", http.StatusForbidden},   // ループバックでない
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, "/_serve/run", nil)
+		r.Host = tt.host
+		if tt.origin != "" {
+			r.Header.Set("Origin", tt.origin)
+		}
+		w := httptest.NewRecorder()
+		if ok := s.allowed(w, r); ok != (tt.want == http.StatusOK) || w.Code != tt.want {
+			t.Errorf("%s Host=%s Origin=%q: allowed=%v code=%d, want %d", tt.method, tt.host, tt.origin, ok, w.Code, tt.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+)
+
+var cmdShow = &command{
+	name:  "show",
+	usage: "show <id>",
+	short: "説明とコピペで動くコードを表示する",
+	run:   runShow,
+}
+
+func runShow(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: golangtips show <id>")
+	}
+	t, ok := tips.Lookup(args[0])
+	if !ok {
+		return fmt.Errorf("unknown tip %q", args[0])
+	}
+
+	fmt.Printf("%s: %s\n", t.ID, t.Title)
+	if len(t.Ruby) > 0 {
+		fmt.Println("Ruby:", strings.Join(t.Ruby, ", "))
+	}
+	if d := strings.TrimSpace(t.Description); d != "" {
+		fmt.Println()
+		fmt.Println(d)
+	}
+	fmt.Println()
+	fmt.Print(t.Snippet())
+	for _, v := range t.Variants {
+		fmt.Printf("\n%s 以降の書き方: golangtips show %s\n", v.Go, v.ID)
+	}
+	return nil
+}
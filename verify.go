@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ashitani/golangtips/pkg/tips"
+)
+
+var cmdVerify = &command{
+	name:  "verify",
+	usage: "verify [-tz zone] [-now time] [-seed n] [-v] [id|category...]",
+	short: "実行結果を // => の注記と突き合わせる",
+	run:   runVerify,
+}
+
+// 注記を書いたときの時刻とシード。time_Now の注記の時刻
+const (
+	verifyNow  = "2015-05-05T07:23:30.757800829+09:00"
+	verifySeed = "1"
+)
+
+func runVerify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	tz := fs.String("tz", "JST", "実行中のtime.Local (JSTなら+0900固定、Localなら変えない、それ以外はtime.LoadLocationの名前)")
+	now := fs.String("now", envOr("GOLANGTIPS_NOW", verifyNow), "time.Now()を止める時刻 (RFC 3339)。空なら止めない")
+	seed := fs.String("seed", envOr("GOLANGTIPS_SEED", verifySeed), "math/randのシード。空なら -now の時刻")
+	verbose := fs.Bool("v", false, "一致したTipsも表示する")
+	fs.Parse(args)
+
+	// Tipsの実行は環境変数から時刻とシードを読む
+	os.Setenv("GOLANGTIPS_NOW", *now)
+	os.Setenv("GOLANGTIPS_SEED", *seed)
+	if _, err := tips.FixedFromEnv(); err != nil {
+		return err
+	}
+
+	var loc *time.Location
+	switch *tz {
+	case "", "Local":
+	case "JST":
+		loc = time.FixedZone("JST", 9*60*60)
+	default:
+		var err error
+		if loc, err = time.LoadLocation(*tz); err != nil {
+			return err
+		}
+	}
+
+	ts, err := selectTips(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, t := range tips.WithVariants(ts) {
+		// 引数なしの場合は注記のあるTipsだけ
+		if fs.NArg() == 0 && len(t.Expectations()) == 0 {
+			continue
+		}
+		r := tips.Verify(t, loc)
+		if r.OK() {
+			if *verbose {
+				fmt.Printf("ok   %s (%d)\n", t.ID, r.Checked)
+			}
+			continue
+		}
+		failed++
+		fmt.Printf("FAIL %s: %s\n", t.ID, t.Title)
+		if r.Err != nil {
+			fmt.Printf("    %s:%d: %v\n", t.File, t.Line, r.Err)
+		}
+		for _, m := range r.Mismatches {
+			got := fmt.Sprintf("%q", m.Got)
+			if m.Got == "" {
+				got = "nothing"
+			}
+			fmt.Printf("    %s:%d: want %q, got %s\n", t.File, m.Line, m.Want, got)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d tips failed", failed)
+	}
+	return nil
+}
+
+// envOr は環境変数 key があればその値を、なければ def を返します。
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}